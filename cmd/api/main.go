@@ -6,6 +6,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/swagger"
@@ -13,8 +14,10 @@ import (
 	"github.com/ngdangkietswe/go-rabbitmq/internal/config"
 	"github.com/ngdangkietswe/go-rabbitmq/internal/handlers"
 	"github.com/ngdangkietswe/go-rabbitmq/internal/middlewares"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/providers"
 	"github.com/ngdangkietswe/go-rabbitmq/internal/routes"
 	"github.com/ngdangkietswe/go-rabbitmq/internal/services"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/tracing"
 	"github.com/ngdangkietswe/go-rabbitmq/pkg/logger"
 	"go.uber.org/zap"
 	"log"
@@ -35,6 +38,14 @@ import (
 // @host localhost:3000
 // @BasePath /api/v1
 // @schemes http https
+// @securityDefinitions.apikey AdminToken
+// @in header
+// @name Authorization
+// @description Bearer token configured as ADMIN_API_TOKEN, sent as "Bearer <token>"
+// @securityDefinitions.apikey InboxToken
+// @in header
+// @name Authorization
+// @description Hex HMAC-SHA256 of the recipient keyed with INBOX_TOKEN_SECRET, or an admin token, sent as "Bearer <token>"
 func main() {
 	config.NewAppConfig("./configs")
 
@@ -44,11 +55,24 @@ func main() {
 
 	appLogger := logger.NewAppLogger(env)
 
-	appLogger.Info("Connecting to RabbitMQ", zap.String("url", rabbitMQUrl))
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	time.Sleep(10 * time.Second) // Simulate some startup delay
+	shutdownTracing, err := tracing.Setup(ctx, "notification-api")
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(shutdownCtx); err != nil {
+			appLogger.Warn("Failed to flush traces", zap.Error(err))
+		}
+	}()
+
+	appLogger.Info("Connecting to RabbitMQ", zap.String("url", services.RedactURL(rabbitMQUrl)))
 
-	rabbitMQ, err := services.NewRabbitMQService(rabbitMQUrl, appLogger)
+	rabbitMQ, err := services.NewRabbitMQService(ctx, rabbitMQUrl, appLogger)
 	if err != nil {
 		log.Fatalf("Failed to connect to RabbitMQ: %v", err)
 	}
@@ -62,22 +86,36 @@ func main() {
 		AppName: "Notification Service v1.0",
 	})
 
-	app.Use(middlewares.NewLogger())
+	app.Use(middlewares.NewRequestID())
+	app.Use(middlewares.NewTracing())
+	app.Use(middlewares.NewLogger(appLogger))
+	app.Use(middlewares.NewMetrics())
 	app.Use(middlewares.NewCORS())
 
 	app.Get("/swagger/*", swagger.HandlerDefault)
 
-	notificationHandler := handlers.NewNotificationHandler(rabbitMQ, appLogger)
+	templateService, err := services.NewTemplateService(appLogger)
+	if err != nil {
+		log.Fatalf("Failed to load templates: %v", err)
+	}
+
+	channels := providers.Channels()
+
+	notificationHandler := handlers.NewNotificationHandler(rabbitMQ, templateService, channels, appLogger)
+	templateHandler := handlers.NewTemplateHandler(templateService, channels, appLogger)
+	inboxHandler := handlers.NewInboxHandler(rabbitMQ, appLogger)
 	rabbitMQHandler := handlers.NewRabbitMQHandler(rabbitMQ, appLogger)
 
-	appRoutes := routes.NewAppRoutes(notificationHandler, rabbitMQHandler)
-	appRoutes.Register(app)
+	healthService := services.NewHealthService(appLogger)
+	healthService.Register("rabbitmq", rabbitMQ.CheckConnection)
+	healthService.Register("rabbitmq_management", rabbitMQ.CheckManagement)
+	healthHandler := handlers.NewHealthHandler(healthService)
 
-	c := make(chan os.Signal, 1)
-	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	appRoutes := routes.NewAppRoutes(notificationHandler, inboxHandler, templateHandler, rabbitMQHandler, healthHandler)
+	appRoutes.Register(app)
 
 	go func() {
-		<-c
+		<-ctx.Done()
 		log.Println("Shutting down gracefully...")
 		if err := app.Shutdown(); err != nil {
 			log.Fatalf("Error shutting down: %v", err)
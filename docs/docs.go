@@ -1,5 +1,4 @@
-// Package docs GENERATED BY SWAG; DO NOT EDIT
-// This file was generated by swaggo/swag
+// Package docs Code generated by swaggo/swag. DO NOT EDIT
 package docs
 
 import "github.com/swaggo/swag"
@@ -44,9 +43,138 @@ const docTemplate = `{
                 }
             }
         },
+        "/api/v1/inbox/{recipient}": {
+            "get": {
+                "security": [
+                    {
+                        "InboxToken": []
+                    }
+                ],
+                "description": "Retrieve the notifications sent to a recipient on the in_app channel, oldest first, without removing them",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Inbox"
+                ],
+                "summary": "List the in-app inbox of a recipient",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recipient, e.g. a user ID",
+                        "name": "recipient",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of messages, defaults to INBOX_MAX_MESSAGES",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.InboxResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Token not valid for this recipient",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/inbox/{recipient}/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "InboxToken": []
+                    }
+                ],
+                "description": "Remove a notification from the inbox of a recipient, e.g. once it has been read",
+                "tags": [
+                    "Inbox"
+                ],
+                "summary": "Delete a message of an in-app inbox",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Recipient, e.g. a user ID",
+                        "name": "recipient",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Message deleted"
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Token not valid for this recipient",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Message not found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    }
+                }
+            }
+        },
         "/api/v1/notifications": {
             "post": {
-                "description": "Send a notification to a recipient",
+                "description": "Send a notification to a recipient, with a message or rendered from a template with template_id, locale and variables",
                 "consumes": [
                     "application/json"
                 ],
@@ -58,6 +186,12 @@ const docTemplate = `{
                 ],
                 "summary": "Send a notification",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Request ID, generated when missing, set as the correlation ID of the published message",
+                        "name": "X-Request-ID",
+                        "in": "header"
+                    },
                     {
                         "description": "Notification request",
                         "name": "notification",
@@ -76,7 +210,7 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid request, or a template that is missing or can't be rendered",
                         "schema": {
                             "$ref": "#/definitions/fiber.Error"
                         }
@@ -86,12 +220,23 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/fiber.Error"
                         }
+                    },
+                    "503": {
+                        "description": "Notification not confirmed by the message broker",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
                     }
                 }
             }
         },
         "/api/v1/rabbitmq/bindings": {
             "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
                 "description": "Retrieve a list of all RabbitMQ bindings",
                 "consumes": [
                     "application/json"
@@ -109,10 +254,22 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "type": "object"
+                                "$ref": "#/definitions/models.Binding"
                             }
                         }
                     },
+                    "401": {
+                        "description": "Missing bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
                     "500": {
                         "description": "Internal server error",
                         "schema": {
@@ -120,11 +277,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/api/v1/rabbitmq/exchanges": {
-            "get": {
-                "description": "Retrieve a list of all RabbitMQ exchanges",
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Bind a queue or an exchange to a source exchange",
                 "consumes": [
                     "application/json"
                 ],
@@ -134,15 +294,47 @@ const docTemplate = `{
                 "tags": [
                     "RabbitMQ"
                 ],
-                "summary": "Get list of RabbitMQ exchanges",
+                "summary": "Create a RabbitMQ binding",
+                "parameters": [
+                    {
+                        "description": "Binding definition",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateBindingRequest"
+                        }
+                    }
+                ],
                 "responses": {
-                    "200": {
-                        "description": "List of RabbitMQ exchanges",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "object"
-                            }
+                            "$ref": "#/definitions/models.Binding"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Source or destination not found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
                         }
                     },
                     "500": {
@@ -154,27 +346,80 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/rabbitmq/queues": {
+        "/api/v1/rabbitmq/bindings/{source}/{destinationType}/{destination}/{propertiesKey}": {
             "get": {
-                "description": "Retrieve a list of all RabbitMQ queues",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
                 ],
+                "description": "Retrieve a binding by its source, destination and properties key",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "RabbitMQ"
                 ],
-                "summary": "Get list of RabbitMQ queues",
+                "summary": "Get a RabbitMQ binding",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Source exchange",
+                        "name": "source",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "queue or exchange",
+                        "name": "destinationType",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Destination queue or exchange",
+                        "name": "destination",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Properties key of the binding, as listed by GET /rabbitmq/bindings",
+                        "name": "propertiesKey",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
-                        "description": "List of RabbitMQ queues",
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "type": "object"
-                            }
+                            "$ref": "#/definitions/models.Binding"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Binding not found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
                         }
                     },
                     "500": {
@@ -184,44 +429,2445 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
-    "definitions": {
-        "fiber.Error": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "type": "integer"
-                },
-                "message": {
-                    "type": "string"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Delete a binding by its source, destination and properties key. Bindings declared from the topology file can't be deleted.",
+                "tags": [
+                    "RabbitMQ"
+                ],
+                "summary": "Delete a RabbitMQ binding",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Source exchange",
+                        "name": "source",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "queue or exchange",
+                        "name": "destinationType",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Destination queue or exchange",
+                        "name": "destination",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Properties key of the binding, as listed by GET /rabbitmq/bindings",
+                        "name": "propertiesKey",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Must be true",
+                        "name": "confirm",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Missing confirmation or invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Binding not found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Binding is managed by the topology file",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    }
                 }
             }
         },
-        "fiber.Map": {
-            "type": "object",
-            "additionalProperties": true
-        },
-        "models.SendNotificationRequest": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "type": "string"
-                },
-                "meta_data": {
-                    "description": "Optional metadata for additional information",
-                    "type": "object",
-                    "additionalProperties": true
-                },
-                "recipient": {
-                    "type": "string"
-                },
-                "title": {
-                    "type": "string"
+        "/api/v1/rabbitmq/dlq": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Peek at the head of a dead-letter queue without removing messages, with their x-death history and last error",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "RabbitMQ"
+                ],
+                "summary": "List dead-lettered notifications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dead-letter queue, defaults to queue_notification_dlq",
+                        "name": "queue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of messages, defaults to 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.DeadLetterMessage"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Drop every message of a dead-letter queue",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "RabbitMQ"
+                ],
+                "summary": "Purge dead-lettered notifications",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Dead-letter queue, defaults to queue_notification_dlq",
+                        "name": "queue",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Must be true",
+                        "name": "confirm",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PurgeDeadLettersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing confirmation or invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/rabbitmq/dlq/replay": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Republish messages of a dead-letter queue to the notification exchange, optionally with an edited payload, and remove them from the queue",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "RabbitMQ"
+                ],
+                "summary": "Replay dead-lettered notifications",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Must be true",
+                        "name": "confirm",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "Replay request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.ReplayDeadLettersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ReplayDeadLettersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing confirmation or invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/rabbitmq/dlq/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Fetch a single message of a dead-letter queue by message ID without removing it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "RabbitMQ"
+                ],
+                "summary": "Get a dead-lettered notification",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Message ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Dead-letter queue, defaults to queue_notification_dlq",
+                        "name": "queue",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.DeadLetterMessage"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Message not found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/rabbitmq/exchanges": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Retrieve a page of RabbitMQ exchanges with their bindings, optionally filtered and sorted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "RabbitMQ"
+                ],
+                "summary": "Get list of RabbitMQ exchanges",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Items per page, at most 500",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter on the name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Treat name as a regular expression",
+                        "name": "use_regex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort on, e.g. name or messages",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Sort in descending order",
+                        "name": "sort_reverse",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. name,messages",
+                        "name": "columns",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of RabbitMQ exchanges",
+                        "schema": {
+                            "$ref": "#/definitions/models.ExchangeList"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid paging parameters",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/rabbitmq/exchanges/{name}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Retrieve an exchange with its stats and the bindings it is the source of",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "RabbitMQ"
+                ],
+                "summary": "Get a RabbitMQ exchange",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Exchange name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Exchange"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Exchange not found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Declare a new exchange, durable unless specified otherwise",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "RabbitMQ"
+                ],
+                "summary": "Create a RabbitMQ exchange",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Exchange name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Exchange definition",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateExchangeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.Exchange"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Exchange already exists",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Delete an exchange together with its bindings. Built-in exchanges and exchanges declared from the topology file can't be deleted.",
+                "tags": [
+                    "RabbitMQ"
+                ],
+                "summary": "Delete a RabbitMQ exchange",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Exchange name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Must be true",
+                        "name": "confirm",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only delete the exchange if it is not the source of any binding",
+                        "name": "if_unused",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Missing confirmation or exchange in use",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Exchange not found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Exchange is managed by the topology file",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/rabbitmq/jobs/{id}": {
+            "get": {
+                "description": "Report the progress of a job started by the move endpoint. Jobs are kept in the memory of the API replica that started them, finished ones for an hour, so other replicas and a restarted one answer 404.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "RabbitMQ"
+                ],
+                "summary": "Get a move job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MoveJob"
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Stop a running move job. Messages already moved stay moved, the others stay in the source queue.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "RabbitMQ"
+                ],
+                "summary": "Cancel a move job",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Job ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.MoveJob"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Job not found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/rabbitmq/queues": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Retrieve a page of RabbitMQ queues, optionally filtered and sorted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "RabbitMQ"
+                ],
+                "summary": "Get list of RabbitMQ queues",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number, starting at 1",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Items per page, at most 500",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter on the name",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Treat name as a regular expression",
+                        "name": "use_regex",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Field to sort on, e.g. name or messages",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Sort in descending order",
+                        "name": "sort_reverse",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated fields to return, e.g. name,messages",
+                        "name": "columns",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Page of RabbitMQ queues",
+                        "schema": {
+                            "$ref": "#/definitions/models.QueueList"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid paging parameters",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/rabbitmq/queues/{name}": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Retrieve a queue with full stats, its consumers and the bindings it is the destination of",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "RabbitMQ"
+                ],
+                "summary": "Get a RabbitMQ queue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Queue name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.QueueDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Queue not found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Declare a new queue, durable unless specified otherwise",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "RabbitMQ"
+                ],
+                "summary": "Create a RabbitMQ queue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Queue name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Queue definition",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateQueueRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.QueueDetails"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Queue already exists",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Delete a queue together with its messages. Queues declared from the topology file can't be deleted.",
+                "tags": [
+                    "RabbitMQ"
+                ],
+                "summary": "Delete a RabbitMQ queue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Queue name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Must be true",
+                        "name": "confirm",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only delete the queue if it has no messages",
+                        "name": "if_empty",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only delete the queue if it has no consumers",
+                        "name": "if_unused",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Missing confirmation or queue not empty or unused",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Queue not found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "409": {
+                        "description": "Queue is managed by the topology file",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/rabbitmq/queues/{name}/contents": {
+            "delete": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Drop the ready messages of a queue, messages delivered but not yet acknowledged are kept",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "RabbitMQ"
+                ],
+                "summary": "Purge a RabbitMQ queue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Queue name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Must be true",
+                        "name": "confirm",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.PurgeQueueResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing confirmation",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Queue not found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/rabbitmq/queues/{name}/messages": {
+            "get": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Fetch messages from the head of a queue and put them back. Notifications are decoded with sensitive fields masked. Peeked messages are flagged as redelivered. Inbox queues are only readable with their recipient's inbox token or an admin token.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "RabbitMQ"
+                ],
+                "summary": "Peek at the messages of a RabbitMQ queue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Queue name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of messages, defaults to 10",
+                        "name": "count",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.QueueMessage"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid bearer token, or an inbox queue of another recipient",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Queue not found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/rabbitmq/queues/{name}/move": {
+            "post": {
+                "security": [
+                    {
+                        "AdminToken": []
+                    }
+                ],
+                "description": "Start a job that consumes messages from a queue and republishes them with confirms to a target exchange and routing key, optionally filtered and limited in count and rate. Messages skipped by the filter stay in the queue. A target that routes back to the source queue is refused. The job runs on the API replica that received the request.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "RabbitMQ"
+                ],
+                "summary": "Move messages to another exchange",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Source queue name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Move request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.MoveMessagesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.MoveJob"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or target routing back to the source queue",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid bearer token",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Source queue or target exchange not found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/rabbitmq/topology/verify": {
+            "get": {
+                "description": "Compare the exchanges, queues and bindings on the broker with the topology file and report drift",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "RabbitMQ"
+                ],
+                "summary": "Verify RabbitMQ topology",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TopologyVerification"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/templates": {
+            "get": {
+                "description": "Retrieve the ID and locales of every message template",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "List message templates",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TemplateSummary"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/templates/{id}": {
+            "get": {
+                "description": "Retrieve the content of a message template in every locale",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Get a message template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TemplateResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/templates/{id}/preview": {
+            "post": {
+                "description": "Render a message template for a channel and locale with the given variables, without sending anything",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Templates"
+                ],
+                "summary": "Preview a message template",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Preview request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.PreviewTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RenderedTemplate"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, or variables the template can't be rendered with",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    },
+                    "404": {
+                        "description": "Template not found",
+                        "schema": {
+                            "$ref": "#/definitions/fiber.Error"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/live": {
+            "get": {
+                "description": "Report that the process is up, without checking its dependencies",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.HealthReport"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "description": "Check the AMQP connection and channels, the management API and, in the worker, the consumers",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "Ready to serve",
+                        "schema": {
+                            "$ref": "#/definitions/models.HealthReport"
+                        }
+                    },
+                    "503": {
+                        "description": "A dependency is unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.HealthReport"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "fiber.Error": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "fiber.Map": {
+            "type": "object",
+            "additionalProperties": true
+        },
+        "models.Binding": {
+            "type": "object",
+            "properties": {
+                "arguments": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "destination": {
+                    "type": "string"
+                },
+                "destination_type": {
+                    "description": "queue or exchange",
+                    "type": "string"
+                },
+                "properties_key": {
+                    "description": "Identifies the binding among those between the same source and destination",
+                    "type": "string"
+                },
+                "routing_key": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "vhost": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Consumer": {
+            "type": "object",
+            "properties": {
+                "ack_required": {
+                    "type": "boolean"
+                },
+                "active": {
+                    "type": "boolean"
+                },
+                "channel": {
+                    "type": "string"
+                },
+                "connection": {
+                    "type": "string"
+                },
+                "consumer_tag": {
+                    "type": "string"
+                },
+                "peer_host": {
+                    "type": "string"
+                },
+                "prefetch_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.CreateBindingRequest": {
+            "type": "object",
+            "properties": {
+                "arguments": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "destination": {
+                    "type": "string"
+                },
+                "destination_type": {
+                    "description": "queue or exchange",
+                    "type": "string"
+                },
+                "routing_key": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreateExchangeRequest": {
+            "type": "object",
+            "properties": {
+                "arguments": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "auto_delete": {
+                    "type": "boolean"
+                },
+                "durable": {
+                    "description": "Defaults to true",
+                    "type": "boolean"
+                },
+                "internal": {
+                    "type": "boolean"
+                },
+                "type": {
+                    "description": "direct, fanout, topic or headers",
+                    "type": "string"
+                }
+            }
+        },
+        "models.CreateQueueRequest": {
+            "type": "object",
+            "properties": {
+                "arguments": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "auto_delete": {
+                    "type": "boolean"
+                },
+                "durable": {
+                    "description": "Defaults to true",
+                    "type": "boolean"
+                },
+                "type": {
+                    "description": "classic (default), quorum or stream",
+                    "type": "string"
+                }
+            }
+        },
+        "models.DeadLetterDeath": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "exchange": {
+                    "type": "string"
+                },
+                "queue": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "routing_keys": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "time": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DeadLetterMessage": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "description": "Raw body when it can't be decoded as a notification",
+                    "type": "string"
+                },
+                "body_omitted": {
+                    "description": "Whether a JSON body that couldn't be decoded, and so masked, was left out",
+                    "type": "boolean"
+                },
+                "deaths": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DeadLetterDeath"
+                    }
+                },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "last_error": {
+                    "type": "string"
+                },
+                "message_id": {
+                    "type": "string"
+                },
+                "notification": {
+                    "$ref": "#/definitions/models.Notification"
+                },
+                "published_at": {
+                    "type": "string"
+                },
+                "queue": {
+                    "type": "string"
+                },
+                "retry_attempt": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Exchange": {
+            "type": "object",
+            "properties": {
+                "arguments": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "auto_delete": {
+                    "type": "boolean"
+                },
+                "bindings": {
+                    "description": "Bindings with this exchange as the source",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Binding"
+                    }
+                },
+                "durable": {
+                    "type": "boolean"
+                },
+                "internal": {
+                    "type": "boolean"
+                },
+                "message_rates": {
+                    "$ref": "#/definitions/models.ExchangeMessageRates"
+                },
+                "name": {
+                    "description": "Empty for the default exchange",
+                    "type": "string"
+                },
+                "policy": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "vhost": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ExchangeList": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Exchange"
+                    }
+                },
+                "paging": {
+                    "$ref": "#/definitions/models.Paging"
+                }
+            }
+        },
+        "models.ExchangeMessageRates": {
+            "type": "object",
+            "properties": {
+                "publish_in": {
+                    "description": "Messages per second published to the exchange",
+                    "type": "number"
+                },
+                "publish_out": {
+                    "description": "Messages per second routed to queues and exchanges",
+                    "type": "number"
+                }
+            }
+        },
+        "models.HealthCheckResult": {
+            "type": "object",
+            "properties": {
+                "duration_ms": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.HealthStatus"
+                }
+            }
+        },
+        "models.HealthReport": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.HealthCheckResult"
+                    }
+                },
+                "status": {
+                    "$ref": "#/definitions/models.HealthStatus"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.HealthStatus": {
+            "type": "string",
+            "enum": [
+                "up",
+                "down"
+            ],
+            "x-enum-varnames": [
+                "HealthStatusUp",
+                "HealthStatusDown"
+            ]
+        },
+        "models.InboxMessage": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta_data": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "received_at": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.InboxResponse": {
+            "type": "object",
+            "properties": {
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.InboxMessage"
+                    }
+                },
+                "recipient": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MessageProperties": {
+            "type": "object",
+            "properties": {
+                "app_id": {
+                    "type": "string"
+                },
+                "content_encoding": {
+                    "type": "string"
+                },
+                "content_type": {
+                    "type": "string"
+                },
+                "correlation_id": {
+                    "type": "string"
+                },
+                "delivery_mode": {
+                    "description": "1 transient, 2 persistent",
+                    "type": "integer"
+                },
+                "expiration": {
+                    "type": "string"
+                },
+                "message_id": {
+                    "type": "string"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "reply_to": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MoveFilter": {
+            "type": "object",
+            "properties": {
+                "fields": {
+                    "description": "JSON body values by dot-separated path, e.g. type or meta_data.tenant",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "headers": {
+                    "description": "Header values, compared as strings",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.MoveJob": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "filter": {
+                    "$ref": "#/definitions/models.MoveFilter"
+                },
+                "finished_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "max_messages": {
+                    "type": "integer"
+                },
+                "moved": {
+                    "type": "integer"
+                },
+                "rate_per_second": {
+                    "type": "integer"
+                },
+                "scanned": {
+                    "description": "Messages fetched from the source queue",
+                    "type": "integer"
+                },
+                "skipped": {
+                    "description": "Messages left in place by the filter",
+                    "type": "integer"
+                },
+                "source_queue": {
+                    "type": "string"
+                },
+                "started_at": {
+                    "type": "string"
+                },
+                "started_by": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.MoveJobStatus"
+                },
+                "stop_reason": {
+                    "description": "Why a finished job stopped",
+                    "type": "string"
+                },
+                "target_exchange": {
+                    "type": "string"
+                },
+                "target_routing_key": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.MoveJobStatus": {
+            "type": "string",
+            "enum": [
+                "running",
+                "completed",
+                "cancelled",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "MoveJobStatusRunning",
+                "MoveJobStatusCompleted",
+                "MoveJobStatusCancelled",
+                "MoveJobStatusFailed"
+            ]
+        },
+        "models.MoveMessagesRequest": {
+            "type": "object",
+            "properties": {
+                "filter": {
+                    "$ref": "#/definitions/models.MoveFilter"
+                },
+                "max_messages": {
+                    "description": "Stop after moving this many messages, defaults to no limit",
+                    "type": "integer"
+                },
+                "rate_per_second": {
+                    "description": "Defaults to no limit",
+                    "type": "integer"
+                },
+                "reset_retries": {
+                    "description": "Drop the retry headers so that moved messages get a fresh retry budget",
+                    "type": "boolean"
+                },
+                "target_exchange": {
+                    "description": "Empty for the default exchange, which routes to the queue named by the routing key",
+                    "type": "string"
+                },
+                "target_routing_key": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Notification": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "Optional error message if the notification fails",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta_data": {
+                    "description": "Optional metadata for additional information",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "recipient": {
+                    "type": "string"
+                },
+                "sent_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/models.NotificationStatus"
+                },
+                "template_id": {
+                    "description": "Template the title and message were rendered from",
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/models.NotificationType"
+                }
+            }
+        },
+        "models.NotificationStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "processing",
+                "sent",
+                "failed"
+            ],
+            "x-enum-varnames": [
+                "NotificationStatusPending",
+                "NotificationStatusProcessing",
+                "NotificationStatusSent",
+                "NotificationStatusFailed"
+            ]
+        },
+        "models.NotificationType": {
+            "type": "string",
+            "enum": [
+                "email",
+                "sms",
+                "push",
+                "webhook",
+                "slack",
+                "in_app"
+            ],
+            "x-enum-varnames": [
+                "NotificationTypeEmail",
+                "NotificationTypeSMS",
+                "NotificationTypePush",
+                "NotificationTypeWebhook",
+                "NotificationTypeSlack",
+                "NotificationTypeInApp"
+            ]
+        },
+        "models.Paging": {
+            "type": "object",
+            "properties": {
+                "filtered_count": {
+                    "description": "Items matching the name filter",
+                    "type": "integer"
+                },
+                "item_count": {
+                    "description": "Items on this page",
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_count": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total_count": {
+                    "description": "Items in the vhost",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.PreviewTemplateRequest": {
+            "type": "object",
+            "properties": {
+                "locale": {
+                    "description": "Defaults to TEMPLATE_DEFAULT_LOCALE",
+                    "type": "string"
+                },
+                "meta_data": {
+                    "description": "Metadata of the notification, also available to the template",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "type": {
+                    "description": "Channel whose variant is rendered, the default content when empty",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.NotificationType"
+                        }
+                    ]
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": true
+                }
+            }
+        },
+        "models.PurgeDeadLettersResponse": {
+            "type": "object",
+            "properties": {
+                "purged": {
+                    "type": "integer"
+                },
+                "queue": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PurgeQueueResponse": {
+            "type": "object",
+            "properties": {
+                "purged": {
+                    "type": "integer"
+                },
+                "queue": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Queue": {
+            "type": "object",
+            "properties": {
+                "arguments": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "auto_delete": {
+                    "type": "boolean"
+                },
+                "consumers": {
+                    "type": "integer"
+                },
+                "durable": {
+                    "type": "boolean"
+                },
+                "exclusive": {
+                    "type": "boolean"
+                },
+                "message_rates": {
+                    "$ref": "#/definitions/models.QueueMessageRates"
+                },
+                "messages": {
+                    "type": "integer"
+                },
+                "messages_ready": {
+                    "type": "integer"
+                },
+                "messages_unacknowledged": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "policy": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "classic, quorum or stream",
+                    "type": "string"
+                },
+                "vhost": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.QueueDetails": {
+            "type": "object",
+            "properties": {
+                "arguments": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "auto_delete": {
+                    "type": "boolean"
+                },
+                "bindings": {
+                    "description": "Bindings with this queue as the destination",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Binding"
+                    }
+                },
+                "consumer_details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Consumer"
+                    }
+                },
+                "consumer_utilisation": {
+                    "description": "Fraction of time the queue can deliver to its consumers immediately",
+                    "type": "number"
+                },
+                "consumers": {
+                    "type": "integer"
+                },
+                "durable": {
+                    "type": "boolean"
+                },
+                "exclusive": {
+                    "type": "boolean"
+                },
+                "idle_since": {
+                    "type": "string"
+                },
+                "memory": {
+                    "description": "Bytes used by the queue process",
+                    "type": "integer"
+                },
+                "message_bytes": {
+                    "type": "integer"
+                },
+                "message_bytes_ready": {
+                    "type": "integer"
+                },
+                "message_bytes_unacknowledged": {
+                    "type": "integer"
+                },
+                "message_rates": {
+                    "$ref": "#/definitions/models.QueueMessageRates"
+                },
+                "messages": {
+                    "type": "integer"
+                },
+                "messages_ready": {
+                    "type": "integer"
+                },
+                "messages_unacknowledged": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "node": {
+                    "type": "string"
+                },
+                "policy": {
+                    "type": "string"
+                },
+                "state": {
+                    "type": "string"
                 },
                 "type": {
+                    "description": "classic, quorum or stream",
+                    "type": "string"
+                },
+                "vhost": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.QueueList": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Queue"
+                    }
+                },
+                "paging": {
+                    "$ref": "#/definitions/models.Paging"
+                }
+            }
+        },
+        "models.QueueMessage": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "description": "Raw body when it isn't a notification",
+                    "type": "string"
+                },
+                "body_bytes": {
+                    "type": "integer"
+                },
+                "body_encoding": {
+                    "description": "string or base64, for the raw body",
+                    "type": "string"
+                },
+                "body_omitted": {
+                    "description": "Whether a JSON body that couldn't be decoded, and so masked, was left out",
+                    "type": "boolean"
+                },
+                "exchange": {
+                    "type": "string"
+                },
+                "headers": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "notification": {
+                    "description": "Decoded JSON body, with sensitive fields masked",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Notification"
+                        }
+                    ]
+                },
+                "properties": {
+                    "$ref": "#/definitions/models.MessageProperties"
+                },
+                "redelivered": {
+                    "type": "boolean"
+                },
+                "routing_key": {
+                    "type": "string"
+                },
+                "truncated": {
+                    "description": "Whether the body was cut off before decoding",
+                    "type": "boolean"
+                }
+            }
+        },
+        "models.QueueMessageRates": {
+            "type": "object",
+            "properties": {
+                "ack": {
+                    "description": "Messages per second acknowledged by consumers",
+                    "type": "number"
+                },
+                "deliver": {
+                    "description": "Messages per second delivered to consumers or fetched with basic.get",
+                    "type": "number"
+                },
+                "publish": {
+                    "description": "Messages per second published to the queue",
+                    "type": "number"
+                },
+                "redeliver": {
+                    "description": "Messages per second delivered again after a requeue",
+                    "type": "number"
+                }
+            }
+        },
+        "models.RenderedTemplate": {
+            "type": "object",
+            "properties": {
+                "html": {
+                    "type": "string"
+                },
+                "locale": {
+                    "description": "Locale the template was found in, after falling back",
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "template_id": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.ReplayDeadLettersRequest": {
+            "type": "object",
+            "properties": {
+                "message_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "overrides": {
+                    "description": "Edited payloads by message ID",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.Notification"
+                    }
+                },
+                "queue": {
+                    "description": "Defaults to the notification dead-letter queue",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ReplayDeadLettersResponse": {
+            "type": "object",
+            "properties": {
+                "not_found": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "replayed": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "replayed_at": {
+                    "type": "string"
+                },
+                "replayed_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SendNotificationRequest": {
+            "type": "object",
+            "properties": {
+                "locale": {
+                    "description": "Locale of the template, defaults to TEMPLATE_DEFAULT_LOCALE",
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "meta_data": {
+                    "description": "Optional metadata for additional information",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "recipient": {
+                    "type": "string"
+                },
+                "template_id": {
+                    "description": "Template to render the title and message from, instead of message",
+                    "type": "string"
+                },
+                "title": {
                     "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/models.NotificationType"
+                },
+                "variables": {
+                    "description": "Template variables, in addition to the metadata",
+                    "type": "object",
+                    "additionalProperties": true
                 }
             }
         },
@@ -231,11 +2877,123 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
+                "request_id": {
+                    "type": "string"
+                },
                 "status": {
+                    "$ref": "#/definitions/models.NotificationStatus"
+                }
+            }
+        },
+        "models.TemplateContent": {
+            "type": "object",
+            "properties": {
+                "channels": {
+                    "description": "Per-channel overrides, e.g. a shorter text for sms",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.TemplateVariant"
+                    }
+                },
+                "html": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TemplateResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "locales": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/models.TemplateContent"
+                    }
+                }
+            }
+        },
+        "models.TemplateSummary": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "locales": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "models.TemplateVariant": {
+            "type": "object",
+            "properties": {
+                "html": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TopologyDrift": {
+            "type": "object",
+            "properties": {
+                "actual": {},
+                "desired": {},
+                "field": {
+                    "description": "\"missing\" when the broker doesn't have the object at all",
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "exchange, queue, binding or policy",
                     "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TopologyVerification": {
+            "type": "object",
+            "properties": {
+                "drifts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.TopologyDrift"
+                    }
+                },
+                "in_sync": {
+                    "type": "boolean"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "AdminToken": {
+            "description": "Bearer token configured as ADMIN_API_TOKEN, sent as \"Bearer \u003ctoken\u003e\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        },
+        "InboxToken": {
+            "description": "Hex HMAC-SHA256 of the recipient keyed with INBOX_TOKEN_SECRET, or an admin token, sent as \"Bearer \u003ctoken\u003e\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
@@ -249,6 +3007,8 @@ var SwaggerInfo = &swag.Spec{
 	Description:      "This is a notification service API with RabbitMQ integration",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
+	LeftDelim:        "{{",
+	RightDelim:       "}}",
 }
 
 func init() {
@@ -0,0 +1,52 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package handlers
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/services"
+)
+
+type HealthHandler struct {
+	health *services.HealthService
+}
+
+func NewHealthHandler(health *services.HealthService) *HealthHandler {
+	return &HealthHandler{
+		health: health,
+	}
+}
+
+// Live godoc
+// @Summary Liveness probe
+// @Description Report that the process is up, without checking its dependencies
+// @Tags Health
+// @Produce json
+// @Success 200 {object} models.HealthReport
+// @Router /health/live [get]
+func (h *HealthHandler) Live(ctx *fiber.Ctx) error {
+	return ctx.Status(fiber.StatusOK).JSON(h.health.Live())
+}
+
+// Ready godoc
+// @Summary Readiness probe
+// @Description Check the AMQP connection and channels, the management API and, in the worker, the consumers
+// @Tags Health
+// @Produce json
+// @Success 200 {object} models.HealthReport "Ready to serve"
+// @Failure 503 {object} models.HealthReport "A dependency is unavailable"
+// @Router /health/ready [get]
+func (h *HealthHandler) Ready(ctx *fiber.Ctx) error {
+	report := h.health.Ready(ctx.UserContext())
+
+	status := fiber.StatusOK
+	if report.Status != models.HealthStatusUp {
+		status = fiber.StatusServiceUnavailable
+	}
+
+	return ctx.Status(status).JSON(report)
+}
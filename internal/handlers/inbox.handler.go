@@ -0,0 +1,96 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/services"
+	"go.uber.org/zap"
+)
+
+type InboxHandler struct {
+	rabbitMQ *services.RabbitMQService
+	logger   *zap.Logger
+}
+
+func NewInboxHandler(rabbitMQ *services.RabbitMQService, logger *zap.Logger) *InboxHandler {
+	return &InboxHandler{
+		rabbitMQ: rabbitMQ,
+		logger:   logger,
+	}
+}
+
+// ListInbox godoc
+// @Summary List the in-app inbox of a recipient
+// @Description Retrieve the notifications sent to a recipient on the in_app channel, oldest first, without removing them
+// @Tags Inbox
+// @Security InboxToken
+// @Produce json
+// @Param recipient path string true "Recipient, e.g. a user ID"
+// @Param limit query int false "Maximum number of messages, defaults to INBOX_MAX_MESSAGES"
+// @Success 200 {object} models.InboxResponse
+// @Failure 400 {object} fiber.Error "Invalid request"
+// @Failure 401 {object} fiber.Error "Missing bearer token"
+// @Failure 403 {object} fiber.Error "Token not valid for this recipient"
+// @Failure 500 {object} fiber.Error "Internal server error"
+// @Router /api/v1/inbox/{recipient} [get]
+func (h *InboxHandler) ListInbox(ctx *fiber.Ctx) error {
+	maxMessages := services.MaxInboxMessages()
+
+	limit := ctx.QueryInt("limit", maxMessages)
+	if limit <= 0 || limit > maxMessages {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxMessages))
+	}
+
+	recipient := pathParam(ctx, "recipient")
+
+	messages, err := h.rabbitMQ.ListInbox(ctx.UserContext(), recipient, limit)
+	if err != nil {
+		return h.inboxError(err, "Failed to list inbox")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(models.InboxResponse{
+		Recipient: recipient,
+		Messages:  messages,
+	})
+}
+
+// DeleteInboxMessage godoc
+// @Summary Delete a message of an in-app inbox
+// @Description Remove a notification from the inbox of a recipient, e.g. once it has been read
+// @Tags Inbox
+// @Security InboxToken
+// @Param recipient path string true "Recipient, e.g. a user ID"
+// @Param id path string true "Notification ID"
+// @Success 204 "Message deleted"
+// @Failure 400 {object} fiber.Error "Invalid request"
+// @Failure 401 {object} fiber.Error "Missing bearer token"
+// @Failure 403 {object} fiber.Error "Token not valid for this recipient"
+// @Failure 404 {object} fiber.Error "Message not found"
+// @Failure 500 {object} fiber.Error "Internal server error"
+// @Router /api/v1/inbox/{recipient}/{id} [delete]
+func (h *InboxHandler) DeleteInboxMessage(ctx *fiber.Ctx) error {
+	if err := h.rabbitMQ.DeleteInboxMessage(ctx.UserContext(), pathParam(ctx, "recipient"), ctx.Params("id")); err != nil {
+		return h.inboxError(err, "Failed to delete inbox message")
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *InboxHandler) inboxError(err error, message string) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidRecipient):
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrMessageNotFound):
+		return fiber.NewError(fiber.StatusNotFound, "Message not found")
+	default:
+		h.logger.Error(message, zap.Error(err))
+		return fiber.NewError(fiber.StatusInternalServerError, message)
+	}
+}
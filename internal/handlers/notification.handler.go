@@ -6,36 +6,52 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/config"
 	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
 	"github.com/ngdangkietswe/go-rabbitmq/internal/services"
 	"github.com/ngdangkietswe/go-rabbitmq/pkg/constants"
 	"go.uber.org/zap"
+	"slices"
+	"time"
 )
 
 type NotificationHandler struct {
-	rabbitMQ *services.RabbitMQService
-	logger   *zap.Logger
+	rabbitMQ       *services.RabbitMQService
+	templates      *services.TemplateService
+	channels       []models.NotificationType
+	logger         *zap.Logger
+	publishTimeout time.Duration
 }
 
-func NewNotificationHandler(rabbitMQ *services.RabbitMQService, logger *zap.Logger) *NotificationHandler {
+// NewNotificationHandler returns the handler of the notification API, which accepts notifications of the
+// given channels, those the worker has a provider for.
+func NewNotificationHandler(rabbitMQ *services.RabbitMQService, templates *services.TemplateService, channels []models.NotificationType, logger *zap.Logger) *NotificationHandler {
 	return &NotificationHandler{
-		rabbitMQ: rabbitMQ,
-		logger:   logger,
+		rabbitMQ:       rabbitMQ,
+		templates:      templates,
+		channels:       channels,
+		logger:         logger,
+		publishTimeout: time.Duration(config.GetInt("NOTIFICATION_PUBLISH_TIMEOUT_MS", 5000)) * time.Millisecond,
 	}
 }
 
 // SendNotification godoc
 // @Summary Send a notification
-// @Description Send a notification to a recipient
+// @Description Send a notification to a recipient, with a message or rendered from a template with template_id, locale and variables
 // @Tags Notifications
 // @Accept json
 // @Produce json
+// @Param X-Request-ID header string false "Request ID, generated when missing, set as the correlation ID of the published message"
 // @Param notification body models.SendNotificationRequest true "Notification request"
 // @Success 202 {object} models.SendNotificationResponse
-// @Failure 400 {object} fiber.Error "Invalid request"
+// @Failure 400 {object} fiber.Error "Invalid request, or a template that is missing or can't be rendered"
 // @Failure 500 {object} fiber.Error "Internal server error"
+// @Failure 503 {object} fiber.Error "Notification not confirmed by the message broker"
 // @Router /api/v1/notifications [post]
 func (h *NotificationHandler) SendNotification(ctx *fiber.Ctx) error {
 	var notificationRequest models.SendNotificationRequest
@@ -44,14 +60,16 @@ func (h *NotificationHandler) SendNotification(ctx *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
 	}
 
-	if notificationRequest.Type == "" || notificationRequest.Recipient == "" || notificationRequest.Message == "" {
+	if notificationRequest.Type == "" || notificationRequest.Recipient == "" || (notificationRequest.Message == "" && notificationRequest.TemplateID == "") {
 		return fiber.NewError(fiber.StatusBadRequest, "Missing required fields")
 	}
 
-	if notificationRequest.Type != models.NotificationTypeEmail &&
-		notificationRequest.Type != models.NotificationTypeSMS &&
-		notificationRequest.Type != models.NotificationTypePush {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid notification type")
+	if notificationRequest.Message != "" && notificationRequest.TemplateID != "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Either message or template_id must be set, not both")
+	}
+
+	if !slices.Contains(h.channels, notificationRequest.Type) {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Invalid notification type, expected one of %v", h.channels))
 	}
 
 	notification := &models.Notification{
@@ -64,14 +82,70 @@ func (h *NotificationHandler) SendNotification(ctx *fiber.Ctx) error {
 		Status:    models.NotificationStatusPending,
 	}
 
-	if err := h.rabbitMQ.PublishMessage(string(constants.ExchangeNotification), string(constants.RoutingKeyNotification), notification); err != nil {
-		return fiber.NewError(fiber.StatusInternalServerError, "Failed to publish notification")
+	if notificationRequest.TemplateID != "" {
+		if err := h.render(notification, &notificationRequest); err != nil {
+			return err
+		}
+	}
+
+	// set on the response by the request ID middleware, from the request header or generated
+	requestID := ctx.GetRespHeader(fiber.HeaderXRequestID)
+
+	publishCtx, cancel := context.WithTimeout(ctx.UserContext(), h.publishTimeout)
+	defer cancel()
+
+	if err := h.rabbitMQ.PublishWithConfirm(publishCtx, string(constants.ExchangeNotification), string(constants.RoutingKeyNotification), notification, services.WithCorrelationID(requestID)); err != nil {
+		h.logger.Error("Failed to publish notification", zap.String("id", notification.ID), zap.String("request_id", requestID), zap.Error(err))
+		switch {
+		case errors.Is(err, services.ErrNotConnected):
+			return fiber.NewError(fiber.StatusServiceUnavailable, "Message broker unavailable")
+		case errors.Is(err, services.ErrUnroutable):
+			return fiber.NewError(fiber.StatusServiceUnavailable, "Notification could not be routed to any queue")
+		case errors.Is(err, services.ErrPublishNacked):
+			return fiber.NewError(fiber.StatusServiceUnavailable, "Message broker rejected the notification")
+		case errors.Is(err, services.ErrNotConfirmed):
+			return fiber.NewError(fiber.StatusServiceUnavailable, "Message broker did not confirm the notification in time")
+		default:
+			return fiber.NewError(fiber.StatusInternalServerError, "Failed to publish notification")
+		}
 	}
 
 	response := models.SendNotificationResponse{
-		ID:     notification.ID,
-		Status: notification.Status,
+		ID:        notification.ID,
+		Status:    notification.Status,
+		RequestID: requestID,
 	}
 
 	return ctx.Status(fiber.StatusAccepted).JSON(response)
 }
+
+// render sets the title and message of notification from the template of the request, and the HTML body of
+// an email. A title given in the request takes precedence over the template's.
+func (h *NotificationHandler) render(notification *models.Notification, request *models.SendNotificationRequest) error {
+	rendered, err := h.templates.Render(request.TemplateID, request.Locale, request.Type, services.TemplateData(request.MetaData, request.Variables))
+	if err != nil {
+		return templateError(h.logger, err, fiber.StatusBadRequest, "Failed to render template")
+	}
+
+	if rendered.Message == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Template rendered an empty message")
+	}
+
+	notification.TemplateID = rendered.TemplateID
+	notification.Locale = rendered.Locale
+	notification.Message = rendered.Message
+	if notification.Title == "" {
+		notification.Title = rendered.Title
+	}
+
+	if rendered.HTML != "" {
+		metaData := make(map[string]interface{}, len(notification.MetaData)+1)
+		for k, v := range notification.MetaData {
+			metaData[k] = v
+		}
+		metaData[string(constants.MetaDataHTML)] = rendered.HTML
+		notification.MetaData = metaData
+	}
+
+	return nil
+}
@@ -6,9 +6,22 @@
 package handlers
 
 import (
+	"errors"
+	"fmt"
 	"github.com/gofiber/fiber/v2"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/middlewares"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
 	"github.com/ngdangkietswe/go-rabbitmq/internal/services"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/constants"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/management"
 	"go.uber.org/zap"
+	"net/url"
+	"strings"
+)
+
+const (
+	defaultPageSize = 100
+	maxPageSize     = 500 // the management API's own limit
 )
 
 type RabbitMQHandler struct {
@@ -25,15 +38,31 @@ func NewRabbitMQHandler(rabbitMQ *services.RabbitMQService, logger *zap.Logger)
 
 // GetListQueues godoc
 // @Summary Get list of RabbitMQ queues
-// @Description Retrieve a list of all RabbitMQ queues
+// @Description Retrieve a page of RabbitMQ queues, optionally filtered and sorted
 // @Tags RabbitMQ
 // @Accept json
 // @Produce json
-// @Success 200 {array} interface{} "List of RabbitMQ queues"
+// @Security AdminToken
+// @Param page query int false "Page number, starting at 1" default(1)
+// @Param page_size query int false "Items per page, at most 500" default(100)
+// @Param name query string false "Filter on the name"
+// @Param use_regex query bool false "Treat name as a regular expression"
+// @Param sort query string false "Field to sort on, e.g. name or messages"
+// @Param sort_reverse query bool false "Sort in descending order"
+// @Param columns query string false "Comma-separated fields to return, e.g. name,messages"
+// @Success 200 {object} models.QueueList "Page of RabbitMQ queues"
+// @Failure 400 {object} fiber.Error "Invalid paging parameters"
+// @Failure 401 {object} fiber.Error "Missing bearer token"
+// @Failure 403 {object} fiber.Error "Invalid bearer token"
 // @Failure 500 {object} fiber.Error "Internal server error"
 // @Router /api/v1/rabbitmq/queues [get]
 func (h *RabbitMQHandler) GetListQueues(ctx *fiber.Ctx) error {
-	queues, err := h.rabbitMQ.GetListQueues()
+	params, err := listParams(ctx)
+	if err != nil {
+		return err
+	}
+
+	queues, err := h.rabbitMQ.GetListQueues(ctx.UserContext(), params)
 	if err != nil {
 		h.logger.Error("Failed to get queues", zap.Error(err))
 		return fiber.NewError(fiber.StatusInternalServerError, "Failed to get queues")
@@ -44,15 +73,31 @@ func (h *RabbitMQHandler) GetListQueues(ctx *fiber.Ctx) error {
 
 // GetListExchanges godoc
 // @Summary Get list of RabbitMQ exchanges
-// @Description Retrieve a list of all RabbitMQ exchanges
+// @Description Retrieve a page of RabbitMQ exchanges with their bindings, optionally filtered and sorted
 // @Tags RabbitMQ
 // @Accept json
 // @Produce json
-// @Success 200 {array} interface{} "List of RabbitMQ exchanges"
+// @Security AdminToken
+// @Param page query int false "Page number, starting at 1" default(1)
+// @Param page_size query int false "Items per page, at most 500" default(100)
+// @Param name query string false "Filter on the name"
+// @Param use_regex query bool false "Treat name as a regular expression"
+// @Param sort query string false "Field to sort on, e.g. name or messages"
+// @Param sort_reverse query bool false "Sort in descending order"
+// @Param columns query string false "Comma-separated fields to return, e.g. name,messages"
+// @Success 200 {object} models.ExchangeList "Page of RabbitMQ exchanges"
+// @Failure 400 {object} fiber.Error "Invalid paging parameters"
+// @Failure 401 {object} fiber.Error "Missing bearer token"
+// @Failure 403 {object} fiber.Error "Invalid bearer token"
 // @Failure 500 {object} fiber.Error "Internal server error"
 // @Router /api/v1/rabbitmq/exchanges [get]
 func (h *RabbitMQHandler) GetListExchanges(ctx *fiber.Ctx) error {
-	exchanges, err := h.rabbitMQ.GetListExchanges()
+	params, err := listParams(ctx)
+	if err != nil {
+		return err
+	}
+
+	exchanges, err := h.rabbitMQ.GetListExchanges(ctx.UserContext(), params)
 	if err != nil {
 		h.logger.Error("Failed to get exchanges", zap.Error(err))
 		return fiber.NewError(fiber.StatusInternalServerError, "Failed to get exchanges")
@@ -67,11 +112,14 @@ func (h *RabbitMQHandler) GetListExchanges(ctx *fiber.Ctx) error {
 // @Tags RabbitMQ
 // @Accept json
 // @Produce json
-// @Success 200 {array} interface{} "List of RabbitMQ bindings"
+// @Security AdminToken
+// @Success 200 {array} models.Binding "List of RabbitMQ bindings"
+// @Failure 401 {object} fiber.Error "Missing bearer token"
+// @Failure 403 {object} fiber.Error "Invalid bearer token"
 // @Failure 500 {object} fiber.Error "Internal server error"
 // @Router /api/v1/rabbitmq/bindings [get]
 func (h *RabbitMQHandler) GetListBindings(ctx *fiber.Ctx) error {
-	bindings, err := h.rabbitMQ.GetListBindings()
+	bindings, err := h.rabbitMQ.GetListBindings(ctx.UserContext())
 	if err != nil {
 		h.logger.Error("Failed to get bindings", zap.Error(err))
 		return fiber.NewError(fiber.StatusInternalServerError, "Failed to get bindings")
@@ -79,3 +127,627 @@ func (h *RabbitMQHandler) GetListBindings(ctx *fiber.Ctx) error {
 
 	return ctx.Status(fiber.StatusOK).JSON(bindings)
 }
+
+// VerifyTopology godoc
+// @Summary Verify RabbitMQ topology
+// @Description Compare the exchanges, queues and bindings on the broker with the topology file and report drift
+// @Tags RabbitMQ
+// @Produce json
+// @Success 200 {object} models.TopologyVerification
+// @Failure 500 {object} fiber.Error "Internal server error"
+// @Router /api/v1/rabbitmq/topology/verify [get]
+func (h *RabbitMQHandler) VerifyTopology(ctx *fiber.Ctx) error {
+	verification, err := h.rabbitMQ.VerifyTopology(ctx.UserContext())
+	if err != nil {
+		h.logger.Error("Failed to verify topology", zap.Error(err))
+		return fiber.NewError(fiber.StatusInternalServerError, "Failed to verify topology")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(verification)
+}
+
+// ListDeadLetters godoc
+// @Summary List dead-lettered notifications
+// @Description Peek at the head of a dead-letter queue without removing messages, with their x-death history and last error
+// @Tags RabbitMQ
+// @Produce json
+// @Security AdminToken
+// @Param queue query string false "Dead-letter queue, defaults to queue_notification_dlq"
+// @Param limit query int false "Maximum number of messages, defaults to 100"
+// @Success 200 {array} models.DeadLetterMessage
+// @Failure 400 {object} fiber.Error "Invalid request"
+// @Failure 401 {object} fiber.Error "Missing bearer token"
+// @Failure 403 {object} fiber.Error "Invalid bearer token"
+// @Failure 500 {object} fiber.Error "Internal server error"
+// @Router /api/v1/rabbitmq/dlq [get]
+func (h *RabbitMQHandler) ListDeadLetters(ctx *fiber.Ctx) error {
+	limit := ctx.QueryInt("limit", 100)
+	if limit <= 0 || limit > services.MaxDeadLetterScan {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", services.MaxDeadLetterScan))
+	}
+
+	messages, err := h.rabbitMQ.ListDeadLetters(ctx.UserContext(), deadLetterQueue(ctx), limit)
+	if err != nil {
+		return h.deadLetterError(err, "Failed to list dead letters")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(messages)
+}
+
+// GetDeadLetter godoc
+// @Summary Get a dead-lettered notification
+// @Description Fetch a single message of a dead-letter queue by message ID without removing it
+// @Tags RabbitMQ
+// @Produce json
+// @Security AdminToken
+// @Param id path string true "Message ID"
+// @Param queue query string false "Dead-letter queue, defaults to queue_notification_dlq"
+// @Success 200 {object} models.DeadLetterMessage
+// @Failure 400 {object} fiber.Error "Invalid request"
+// @Failure 401 {object} fiber.Error "Missing bearer token"
+// @Failure 403 {object} fiber.Error "Invalid bearer token"
+// @Failure 404 {object} fiber.Error "Message not found"
+// @Failure 500 {object} fiber.Error "Internal server error"
+// @Router /api/v1/rabbitmq/dlq/{id} [get]
+func (h *RabbitMQHandler) GetDeadLetter(ctx *fiber.Ctx) error {
+	message, err := h.rabbitMQ.GetDeadLetter(ctx.UserContext(), deadLetterQueue(ctx), ctx.Params("id"))
+	if err != nil {
+		return h.deadLetterError(err, "Failed to get dead letter")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(message)
+}
+
+// ReplayDeadLetters godoc
+// @Summary Replay dead-lettered notifications
+// @Description Republish messages of a dead-letter queue to the notification exchange, optionally with an edited payload, and remove them from the queue
+// @Tags RabbitMQ
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param confirm query bool true "Must be true"
+// @Param request body models.ReplayDeadLettersRequest true "Replay request"
+// @Success 200 {object} models.ReplayDeadLettersResponse
+// @Failure 400 {object} fiber.Error "Missing confirmation or invalid request"
+// @Failure 401 {object} fiber.Error "Missing bearer token"
+// @Failure 403 {object} fiber.Error "Invalid bearer token"
+// @Failure 500 {object} fiber.Error "Internal server error"
+// @Router /api/v1/rabbitmq/dlq/replay [post]
+func (h *RabbitMQHandler) ReplayDeadLetters(ctx *fiber.Ctx) error {
+	if err := requireConfirmation(ctx, "replay dead letters"); err != nil {
+		return err
+	}
+
+	var request models.ReplayDeadLettersRequest
+
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if len(request.MessageIDs) == 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "Missing message_ids")
+	}
+
+	response, err := h.rabbitMQ.ReplayDeadLetters(ctx.UserContext(), &request, actor(ctx))
+	if err != nil {
+		return h.deadLetterError(err, "Failed to replay dead letters")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(response)
+}
+
+// PurgeDeadLetters godoc
+// @Summary Purge dead-lettered notifications
+// @Description Drop every message of a dead-letter queue
+// @Tags RabbitMQ
+// @Produce json
+// @Security AdminToken
+// @Param queue query string false "Dead-letter queue, defaults to queue_notification_dlq"
+// @Param confirm query bool true "Must be true"
+// @Success 200 {object} models.PurgeDeadLettersResponse
+// @Failure 400 {object} fiber.Error "Missing confirmation or invalid request"
+// @Failure 401 {object} fiber.Error "Missing bearer token"
+// @Failure 403 {object} fiber.Error "Invalid bearer token"
+// @Failure 500 {object} fiber.Error "Internal server error"
+// @Router /api/v1/rabbitmq/dlq [delete]
+func (h *RabbitMQHandler) PurgeDeadLetters(ctx *fiber.Ctx) error {
+	queue := deadLetterQueue(ctx)
+
+	if err := requireConfirmation(ctx, "purge dead-letter queue "+queue); err != nil {
+		return err
+	}
+
+	purged, err := h.rabbitMQ.PurgeDeadLetters(ctx.UserContext(), queue, actor(ctx))
+	if err != nil {
+		return h.deadLetterError(err, "Failed to purge dead letters")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(models.PurgeDeadLettersResponse{
+		Queue:  queue,
+		Purged: purged,
+	})
+}
+
+func (h *RabbitMQHandler) deadLetterError(err error, message string) error {
+	switch {
+	case errors.Is(err, services.ErrUnknownDeadLetterQueue):
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("queue must be one of %v", h.rabbitMQ.DeadLetterQueues()))
+	case errors.Is(err, services.ErrMessageNotFound):
+		return fiber.NewError(fiber.StatusNotFound, "Message not found")
+	default:
+		h.logger.Error(message, zap.Error(err))
+		return fiber.NewError(fiber.StatusInternalServerError, message)
+	}
+}
+
+func deadLetterQueue(ctx *fiber.Ctx) string {
+	return ctx.Query("queue", string(constants.QueueNotificationDeadLetter))
+}
+
+// actor identifies who performs an administrative operation, for the audit log: the admin whose token
+// authenticated the request.
+func actor(ctx *fiber.Ctx) string {
+	return middlewares.AdminName(ctx)
+}
+
+// listParams reads the paging, filtering and sorting query parameters of the list endpoints.
+func listParams(ctx *fiber.Ctx) (*management.ListParams, error) {
+	params := &management.ListParams{
+		Page:        ctx.QueryInt("page", 1),
+		PageSize:    ctx.QueryInt("page_size", defaultPageSize),
+		Name:        ctx.Query("name"),
+		UseRegex:    ctx.QueryBool("use_regex"),
+		Sort:        ctx.Query("sort"),
+		SortReverse: ctx.QueryBool("sort_reverse"),
+	}
+
+	if params.Page < 1 {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "page must be at least 1")
+	}
+	if params.PageSize < 1 || params.PageSize > maxPageSize {
+		return nil, fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("page_size must be between 1 and %d", maxPageSize))
+	}
+
+	if columns := ctx.Query("columns"); columns != "" {
+		params.Columns = strings.Split(columns, ",")
+	}
+
+	return params, nil
+}
+
+// GetQueue godoc
+// @Summary Get a RabbitMQ queue
+// @Description Retrieve a queue with full stats, its consumers and the bindings it is the destination of
+// @Tags RabbitMQ
+// @Produce json
+// @Security AdminToken
+// @Param name path string true "Queue name"
+// @Success 200 {object} models.QueueDetails
+// @Failure 401 {object} fiber.Error "Missing bearer token"
+// @Failure 403 {object} fiber.Error "Invalid bearer token"
+// @Failure 404 {object} fiber.Error "Queue not found"
+// @Failure 500 {object} fiber.Error "Internal server error"
+// @Router /api/v1/rabbitmq/queues/{name} [get]
+func (h *RabbitMQHandler) GetQueue(ctx *fiber.Ctx) error {
+	queue, err := h.rabbitMQ.GetQueue(ctx.UserContext(), pathParam(ctx, "name"))
+	if err != nil {
+		return h.adminError(err, "Failed to get queue")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(queue)
+}
+
+// PeekQueueMessages godoc
+// @Summary Peek at the messages of a RabbitMQ queue
+// @Description Fetch messages from the head of a queue and put them back. Notifications are decoded with sensitive fields masked. Peeked messages are flagged as redelivered. Inbox queues are only readable with their recipient's inbox token or an admin token.
+// @Tags RabbitMQ
+// @Produce json
+// @Security AdminToken
+// @Param name path string true "Queue name"
+// @Param count query int false "Number of messages, defaults to 10" default(10)
+// @Success 200 {array} models.QueueMessage
+// @Failure 400 {object} fiber.Error "Invalid request"
+// @Failure 401 {object} fiber.Error "Missing bearer token"
+// @Failure 403 {object} fiber.Error "Invalid bearer token, or an inbox queue of another recipient"
+// @Failure 404 {object} fiber.Error "Queue not found"
+// @Failure 500 {object} fiber.Error "Internal server error"
+// @Router /api/v1/rabbitmq/queues/{name}/messages [get]
+func (h *RabbitMQHandler) PeekQueueMessages(ctx *fiber.Ctx) error {
+	count := ctx.QueryInt("count", 10)
+	if count <= 0 || count > services.MaxPeekMessages {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("count must be between 1 and %d", services.MaxPeekMessages))
+	}
+
+	canReadInbox := func(recipient string) bool {
+		return middlewares.InboxAuthorized(ctx, recipient)
+	}
+
+	messages, err := h.rabbitMQ.PeekMessages(ctx.UserContext(), pathParam(ctx, "name"), count, canReadInbox)
+	if err != nil {
+		return h.adminError(err, "Failed to peek at queue messages")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(messages)
+}
+
+// CreateQueue godoc
+// @Summary Create a RabbitMQ queue
+// @Description Declare a new queue, durable unless specified otherwise
+// @Tags RabbitMQ
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param name path string true "Queue name"
+// @Param request body models.CreateQueueRequest true "Queue definition"
+// @Success 201 {object} models.QueueDetails
+// @Failure 400 {object} fiber.Error "Invalid request"
+// @Failure 401 {object} fiber.Error "Missing bearer token"
+// @Failure 403 {object} fiber.Error "Invalid bearer token"
+// @Failure 409 {object} fiber.Error "Queue already exists"
+// @Failure 500 {object} fiber.Error "Internal server error"
+// @Router /api/v1/rabbitmq/queues/{name} [post]
+func (h *RabbitMQHandler) CreateQueue(ctx *fiber.Ctx) error {
+	var request models.CreateQueueRequest
+
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	queue, err := h.rabbitMQ.CreateQueue(ctx.UserContext(), pathParam(ctx, "name"), &request, actor(ctx))
+	if err != nil {
+		return h.adminError(err, "Failed to create queue")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(queue)
+}
+
+// DeleteQueue godoc
+// @Summary Delete a RabbitMQ queue
+// @Description Delete a queue together with its messages. Queues declared from the topology file can't be deleted.
+// @Tags RabbitMQ
+// @Security AdminToken
+// @Param name path string true "Queue name"
+// @Param confirm query bool true "Must be true"
+// @Param if_empty query bool false "Only delete the queue if it has no messages"
+// @Param if_unused query bool false "Only delete the queue if it has no consumers"
+// @Success 204
+// @Failure 400 {object} fiber.Error "Missing confirmation or queue not empty or unused"
+// @Failure 401 {object} fiber.Error "Missing bearer token"
+// @Failure 403 {object} fiber.Error "Invalid bearer token"
+// @Failure 404 {object} fiber.Error "Queue not found"
+// @Failure 409 {object} fiber.Error "Queue is managed by the topology file"
+// @Failure 500 {object} fiber.Error "Internal server error"
+// @Router /api/v1/rabbitmq/queues/{name} [delete]
+func (h *RabbitMQHandler) DeleteQueue(ctx *fiber.Ctx) error {
+	name := pathParam(ctx, "name")
+
+	if err := requireConfirmation(ctx, "delete queue "+name); err != nil {
+		return err
+	}
+
+	if err := h.rabbitMQ.DeleteQueue(ctx.UserContext(), name, ctx.QueryBool("if_empty"), ctx.QueryBool("if_unused"), actor(ctx)); err != nil {
+		return h.adminError(err, "Failed to delete queue")
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+// PurgeQueue godoc
+// @Summary Purge a RabbitMQ queue
+// @Description Drop the ready messages of a queue, messages delivered but not yet acknowledged are kept
+// @Tags RabbitMQ
+// @Produce json
+// @Security AdminToken
+// @Param name path string true "Queue name"
+// @Param confirm query bool true "Must be true"
+// @Success 200 {object} models.PurgeQueueResponse
+// @Failure 400 {object} fiber.Error "Missing confirmation"
+// @Failure 401 {object} fiber.Error "Missing bearer token"
+// @Failure 403 {object} fiber.Error "Invalid bearer token"
+// @Failure 404 {object} fiber.Error "Queue not found"
+// @Failure 500 {object} fiber.Error "Internal server error"
+// @Router /api/v1/rabbitmq/queues/{name}/contents [delete]
+func (h *RabbitMQHandler) PurgeQueue(ctx *fiber.Ctx) error {
+	name := pathParam(ctx, "name")
+
+	if err := requireConfirmation(ctx, "purge queue "+name); err != nil {
+		return err
+	}
+
+	purged, err := h.rabbitMQ.PurgeQueue(ctx.UserContext(), name, actor(ctx))
+	if err != nil {
+		return h.adminError(err, "Failed to purge queue")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(models.PurgeQueueResponse{
+		Queue:  name,
+		Purged: purged,
+	})
+}
+
+// MoveQueueMessages godoc
+// @Summary Move messages to another exchange
+// @Description Start a job that consumes messages from a queue and republishes them with confirms to a target exchange and routing key, optionally filtered and limited in count and rate. Messages skipped by the filter stay in the queue. A target that routes back to the source queue is refused. The job runs on the API replica that received the request.
+// @Tags RabbitMQ
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param name path string true "Source queue name"
+// @Param request body models.MoveMessagesRequest true "Move request"
+// @Success 202 {object} models.MoveJob
+// @Failure 400 {object} fiber.Error "Invalid request or target routing back to the source queue"
+// @Failure 401 {object} fiber.Error "Missing bearer token"
+// @Failure 403 {object} fiber.Error "Invalid bearer token"
+// @Failure 404 {object} fiber.Error "Source queue or target exchange not found"
+// @Failure 500 {object} fiber.Error "Internal server error"
+// @Router /api/v1/rabbitmq/queues/{name}/move [post]
+func (h *RabbitMQHandler) MoveQueueMessages(ctx *fiber.Ctx) error {
+	var request models.MoveMessagesRequest
+
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	job, err := h.rabbitMQ.StartMove(ctx.UserContext(), pathParam(ctx, "name"), &request, actor(ctx))
+	if err != nil {
+		return h.adminError(err, "Failed to start moving messages")
+	}
+
+	return ctx.Status(fiber.StatusAccepted).JSON(job)
+}
+
+// GetMoveJob godoc
+// @Summary Get a move job
+// @Description Report the progress of a job started by the move endpoint. Jobs are kept in the memory of the API replica that started them, finished ones for an hour, so other replicas and a restarted one answer 404.
+// @Tags RabbitMQ
+// @Produce json
+// @Param id path string true "Job ID"
+// @Success 200 {object} models.MoveJob
+// @Failure 404 {object} fiber.Error "Job not found"
+// @Router /api/v1/rabbitmq/jobs/{id} [get]
+func (h *RabbitMQHandler) GetMoveJob(ctx *fiber.Ctx) error {
+	job, err := h.rabbitMQ.GetMoveJob(ctx.Params("id"))
+	if err != nil {
+		return h.adminError(err, "Failed to get job")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(job)
+}
+
+// CancelMoveJob godoc
+// @Summary Cancel a move job
+// @Description Stop a running move job. Messages already moved stay moved, the others stay in the source queue.
+// @Tags RabbitMQ
+// @Produce json
+// @Security AdminToken
+// @Param id path string true "Job ID"
+// @Success 200 {object} models.MoveJob
+// @Failure 401 {object} fiber.Error "Missing bearer token"
+// @Failure 403 {object} fiber.Error "Invalid bearer token"
+// @Failure 404 {object} fiber.Error "Job not found"
+// @Router /api/v1/rabbitmq/jobs/{id} [delete]
+func (h *RabbitMQHandler) CancelMoveJob(ctx *fiber.Ctx) error {
+	job, err := h.rabbitMQ.CancelMoveJob(ctx.Params("id"), actor(ctx))
+	if err != nil {
+		return h.adminError(err, "Failed to cancel job")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(job)
+}
+
+// GetExchange godoc
+// @Summary Get a RabbitMQ exchange
+// @Description Retrieve an exchange with its stats and the bindings it is the source of
+// @Tags RabbitMQ
+// @Produce json
+// @Security AdminToken
+// @Param name path string true "Exchange name"
+// @Success 200 {object} models.Exchange
+// @Failure 401 {object} fiber.Error "Missing bearer token"
+// @Failure 403 {object} fiber.Error "Invalid bearer token"
+// @Failure 404 {object} fiber.Error "Exchange not found"
+// @Failure 500 {object} fiber.Error "Internal server error"
+// @Router /api/v1/rabbitmq/exchanges/{name} [get]
+func (h *RabbitMQHandler) GetExchange(ctx *fiber.Ctx) error {
+	exchange, err := h.rabbitMQ.GetExchange(ctx.UserContext(), pathParam(ctx, "name"))
+	if err != nil {
+		return h.adminError(err, "Failed to get exchange")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(exchange)
+}
+
+// CreateExchange godoc
+// @Summary Create a RabbitMQ exchange
+// @Description Declare a new exchange, durable unless specified otherwise
+// @Tags RabbitMQ
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param name path string true "Exchange name"
+// @Param request body models.CreateExchangeRequest true "Exchange definition"
+// @Success 201 {object} models.Exchange
+// @Failure 400 {object} fiber.Error "Invalid request"
+// @Failure 401 {object} fiber.Error "Missing bearer token"
+// @Failure 403 {object} fiber.Error "Invalid bearer token"
+// @Failure 409 {object} fiber.Error "Exchange already exists"
+// @Failure 500 {object} fiber.Error "Internal server error"
+// @Router /api/v1/rabbitmq/exchanges/{name} [post]
+func (h *RabbitMQHandler) CreateExchange(ctx *fiber.Ctx) error {
+	var request models.CreateExchangeRequest
+
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	exchange, err := h.rabbitMQ.CreateExchange(ctx.UserContext(), pathParam(ctx, "name"), &request, actor(ctx))
+	if err != nil {
+		return h.adminError(err, "Failed to create exchange")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(exchange)
+}
+
+// DeleteExchange godoc
+// @Summary Delete a RabbitMQ exchange
+// @Description Delete an exchange together with its bindings. Built-in exchanges and exchanges declared from the topology file can't be deleted.
+// @Tags RabbitMQ
+// @Security AdminToken
+// @Param name path string true "Exchange name"
+// @Param confirm query bool true "Must be true"
+// @Param if_unused query bool false "Only delete the exchange if it is not the source of any binding"
+// @Success 204
+// @Failure 400 {object} fiber.Error "Missing confirmation or exchange in use"
+// @Failure 401 {object} fiber.Error "Missing bearer token"
+// @Failure 403 {object} fiber.Error "Invalid bearer token"
+// @Failure 404 {object} fiber.Error "Exchange not found"
+// @Failure 409 {object} fiber.Error "Exchange is managed by the topology file"
+// @Failure 500 {object} fiber.Error "Internal server error"
+// @Router /api/v1/rabbitmq/exchanges/{name} [delete]
+func (h *RabbitMQHandler) DeleteExchange(ctx *fiber.Ctx) error {
+	name := pathParam(ctx, "name")
+
+	if err := requireConfirmation(ctx, "delete exchange "+name); err != nil {
+		return err
+	}
+
+	if err := h.rabbitMQ.DeleteExchange(ctx.UserContext(), name, ctx.QueryBool("if_unused"), actor(ctx)); err != nil {
+		return h.adminError(err, "Failed to delete exchange")
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+// GetBinding godoc
+// @Summary Get a RabbitMQ binding
+// @Description Retrieve a binding by its source, destination and properties key
+// @Tags RabbitMQ
+// @Produce json
+// @Security AdminToken
+// @Param source path string true "Source exchange"
+// @Param destinationType path string true "queue or exchange"
+// @Param destination path string true "Destination queue or exchange"
+// @Param propertiesKey path string true "Properties key of the binding, as listed by GET /rabbitmq/bindings"
+// @Success 200 {object} models.Binding
+// @Failure 400 {object} fiber.Error "Invalid request"
+// @Failure 401 {object} fiber.Error "Missing bearer token"
+// @Failure 403 {object} fiber.Error "Invalid bearer token"
+// @Failure 404 {object} fiber.Error "Binding not found"
+// @Failure 500 {object} fiber.Error "Internal server error"
+// @Router /api/v1/rabbitmq/bindings/{source}/{destinationType}/{destination}/{propertiesKey} [get]
+func (h *RabbitMQHandler) GetBinding(ctx *fiber.Ctx) error {
+	binding, err := h.rabbitMQ.GetBinding(ctx.UserContext(), pathParam(ctx, "source"), pathParam(ctx, "destinationType"), pathParam(ctx, "destination"), pathParam(ctx, "propertiesKey"))
+	if err != nil {
+		return h.adminError(err, "Failed to get binding")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(binding)
+}
+
+// CreateBinding godoc
+// @Summary Create a RabbitMQ binding
+// @Description Bind a queue or an exchange to a source exchange
+// @Tags RabbitMQ
+// @Accept json
+// @Produce json
+// @Security AdminToken
+// @Param request body models.CreateBindingRequest true "Binding definition"
+// @Success 201 {object} models.Binding
+// @Failure 400 {object} fiber.Error "Invalid request"
+// @Failure 401 {object} fiber.Error "Missing bearer token"
+// @Failure 403 {object} fiber.Error "Invalid bearer token"
+// @Failure 404 {object} fiber.Error "Source or destination not found"
+// @Failure 500 {object} fiber.Error "Internal server error"
+// @Router /api/v1/rabbitmq/bindings [post]
+func (h *RabbitMQHandler) CreateBinding(ctx *fiber.Ctx) error {
+	var request models.CreateBindingRequest
+
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if request.Destination == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Missing destination")
+	}
+
+	binding, err := h.rabbitMQ.CreateBinding(ctx.UserContext(), &request, actor(ctx))
+	if err != nil {
+		return h.adminError(err, "Failed to create binding")
+	}
+
+	return ctx.Status(fiber.StatusCreated).JSON(binding)
+}
+
+// DeleteBinding godoc
+// @Summary Delete a RabbitMQ binding
+// @Description Delete a binding by its source, destination and properties key. Bindings declared from the topology file can't be deleted.
+// @Tags RabbitMQ
+// @Security AdminToken
+// @Param source path string true "Source exchange"
+// @Param destinationType path string true "queue or exchange"
+// @Param destination path string true "Destination queue or exchange"
+// @Param propertiesKey path string true "Properties key of the binding, as listed by GET /rabbitmq/bindings"
+// @Param confirm query bool true "Must be true"
+// @Success 204
+// @Failure 400 {object} fiber.Error "Missing confirmation or invalid request"
+// @Failure 401 {object} fiber.Error "Missing bearer token"
+// @Failure 403 {object} fiber.Error "Invalid bearer token"
+// @Failure 404 {object} fiber.Error "Binding not found"
+// @Failure 409 {object} fiber.Error "Binding is managed by the topology file"
+// @Failure 500 {object} fiber.Error "Internal server error"
+// @Router /api/v1/rabbitmq/bindings/{source}/{destinationType}/{destination}/{propertiesKey} [delete]
+func (h *RabbitMQHandler) DeleteBinding(ctx *fiber.Ctx) error {
+	source, destination := pathParam(ctx, "source"), pathParam(ctx, "destination")
+
+	if err := requireConfirmation(ctx, fmt.Sprintf("delete binding %s -> %s", source, destination)); err != nil {
+		return err
+	}
+
+	if err := h.rabbitMQ.DeleteBinding(ctx.UserContext(), source, pathParam(ctx, "destinationType"), destination, pathParam(ctx, "propertiesKey"), actor(ctx)); err != nil {
+		return h.adminError(err, "Failed to delete binding")
+	}
+
+	return ctx.SendStatus(fiber.StatusNoContent)
+}
+
+func (h *RabbitMQHandler) adminError(err error, message string) error {
+	var statusErr *management.StatusError
+
+	switch {
+	case errors.Is(err, management.ErrNotFound), errors.Is(err, services.ErrJobNotFound):
+		return fiber.NewError(fiber.StatusNotFound, "Not found")
+	case errors.Is(err, services.ErrInvalidDefinition):
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	case errors.Is(err, services.ErrAlreadyExists), errors.Is(err, services.ErrManagedByTopology):
+		return fiber.NewError(fiber.StatusConflict, err.Error())
+	case errors.Is(err, services.ErrInboxQueue):
+		return fiber.NewError(fiber.StatusForbidden, err.Error())
+	case errors.As(err, &statusErr) && statusErr.StatusCode < 500:
+		// refused by the broker, e.g. inequivalent arguments or a queue that is not empty
+		return fiber.NewError(fiber.StatusBadRequest, statusErr.Reason)
+	case errors.Is(err, services.ErrNotConnected):
+		return fiber.NewError(fiber.StatusServiceUnavailable, "Message broker unavailable")
+	default:
+		h.logger.Error(message, zap.Error(err))
+		return fiber.NewError(fiber.StatusInternalServerError, message)
+	}
+}
+
+// requireConfirmation rejects a destructive operation unless the caller passed confirm=true.
+func requireConfirmation(ctx *fiber.Ctx, operation string) error {
+	if !ctx.QueryBool("confirm") {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Pass confirm=true to %s", operation))
+	}
+
+	return nil
+}
+
+// pathParam returns a route parameter unescaped, since names may contain characters such as '/'.
+func pathParam(ctx *fiber.Ctx, key string) string {
+	value, err := url.PathUnescape(ctx.Params(key))
+	if err != nil {
+		return ctx.Params(key)
+	}
+
+	return value
+}
@@ -0,0 +1,104 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/services"
+	"go.uber.org/zap"
+	"slices"
+)
+
+type TemplateHandler struct {
+	templates *services.TemplateService
+	channels  []models.NotificationType
+	logger    *zap.Logger
+}
+
+func NewTemplateHandler(templates *services.TemplateService, channels []models.NotificationType, logger *zap.Logger) *TemplateHandler {
+	return &TemplateHandler{
+		templates: templates,
+		channels:  channels,
+		logger:    logger,
+	}
+}
+
+// ListTemplates godoc
+// @Summary List message templates
+// @Description Retrieve the ID and locales of every message template
+// @Tags Templates
+// @Produce json
+// @Success 200 {array} models.TemplateSummary
+// @Router /api/v1/templates [get]
+func (h *TemplateHandler) ListTemplates(ctx *fiber.Ctx) error {
+	return ctx.Status(fiber.StatusOK).JSON(h.templates.List())
+}
+
+// GetTemplate godoc
+// @Summary Get a message template
+// @Description Retrieve the content of a message template in every locale
+// @Tags Templates
+// @Produce json
+// @Param id path string true "Template ID"
+// @Success 200 {object} models.TemplateResponse
+// @Failure 404 {object} fiber.Error "Template not found"
+// @Router /api/v1/templates/{id} [get]
+func (h *TemplateHandler) GetTemplate(ctx *fiber.Ctx) error {
+	template, err := h.templates.Get(ctx.Params("id"))
+	if err != nil {
+		return templateError(h.logger, err, fiber.StatusNotFound, "Failed to get template")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(template)
+}
+
+// PreviewTemplate godoc
+// @Summary Preview a message template
+// @Description Render a message template for a channel and locale with the given variables, without sending anything
+// @Tags Templates
+// @Accept json
+// @Produce json
+// @Param id path string true "Template ID"
+// @Param request body models.PreviewTemplateRequest true "Preview request"
+// @Success 200 {object} models.RenderedTemplate
+// @Failure 400 {object} fiber.Error "Invalid request, or variables the template can't be rendered with"
+// @Failure 404 {object} fiber.Error "Template not found"
+// @Router /api/v1/templates/{id}/preview [post]
+func (h *TemplateHandler) PreviewTemplate(ctx *fiber.Ctx) error {
+	var request models.PreviewTemplateRequest
+
+	if err := ctx.BodyParser(&request); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Invalid request body")
+	}
+
+	if request.Type != "" && !slices.Contains(h.channels, request.Type) {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("Invalid notification type, expected one of %v", h.channels))
+	}
+
+	rendered, err := h.templates.Render(ctx.Params("id"), request.Locale, request.Type, services.TemplateData(request.MetaData, request.Variables))
+	if err != nil {
+		return templateError(h.logger, err, fiber.StatusNotFound, "Failed to render template")
+	}
+
+	return ctx.Status(fiber.StatusOK).JSON(rendered)
+}
+
+// templateError maps the errors of the template service to responses, a missing template to notFoundStatus:
+// 404 when the template is the resource of the request, 400 when it is referenced by its body.
+func templateError(logger *zap.Logger, err error, notFoundStatus int, message string) error {
+	switch {
+	case errors.Is(err, services.ErrTemplateNotFound):
+		return fiber.NewError(notFoundStatus, err.Error())
+	case errors.Is(err, services.ErrInvalidTemplate), errors.Is(err, services.ErrRenderTemplate):
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	default:
+		logger.Error(message, zap.Error(err))
+		return fiber.NewError(fiber.StatusInternalServerError, message)
+	}
+}
@@ -0,0 +1,116 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+// Package metrics holds the Prometheus collectors of the API and the worker. They are registered with the
+// default registry, which also exports the Go runtime and process collectors, and served on /metrics.
+//
+// Metric names and labels are part of the service's interface, dashboards and alerts depend on them:
+//
+//	notification_messages_published_total{exchange, routing_key, outcome}
+//	    Messages published. outcome is confirmed, unroutable, nacked, not_confirmed, not_connected or error
+//	    for publishes waiting for a confirm, sent for fire-and-forget publishes.
+//	notification_publish_confirm_duration_seconds{exchange}
+//	    Time from publishing a message to the broker's confirm, for confirmed publishes.
+//	notification_messages_consumed_total{queue}
+//	    Deliveries received by consumers.
+//	notification_messages_settled_total{queue, outcome}
+//	    Deliveries settled by consumers. outcome is acked, rejected (dead-lettered), requeued, retried
+//	    (scheduled on a delay queue) or parked (retries exhausted).
+//	notification_processing_duration_seconds{type, result}
+//	    Duration of ProcessNotification by notification type. result is success or failure.
+//	notification_rabbitmq_reconnects_total{result}
+//	    Attempts to recover the broker connection or channels. result is success or failure.
+//	notification_http_requests_total{method, route, status}
+//	    HTTP requests served, by route template, e.g. /api/v1/rabbitmq/queues/:name.
+//	notification_http_request_duration_seconds{method, route}
+//	    Latency of HTTP requests by route template.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "notification"
+
+const (
+	PublishOutcomeConfirmed    = "confirmed"
+	PublishOutcomeUnroutable   = "unroutable"
+	PublishOutcomeNacked       = "nacked"
+	PublishOutcomeNotConfirmed = "not_confirmed"
+	PublishOutcomeNotConnected = "not_connected"
+	PublishOutcomeError        = "error"
+	PublishOutcomeSent         = "sent"
+
+	SettleOutcomeAcked    = "acked"
+	SettleOutcomeRejected = "rejected"
+	SettleOutcomeRequeued = "requeued"
+	SettleOutcomeRetried  = "retried"
+	SettleOutcomeParked   = "parked"
+
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+var (
+	MessagesPublished = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "messages_published_total",
+		Help:      "Messages published, by exchange, routing key and outcome.",
+	}, []string{"exchange", "routing_key", "outcome"})
+
+	PublishConfirmDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "publish_confirm_duration_seconds",
+		Help:      "Time from publishing a message to the broker's confirm.",
+		Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+	}, []string{"exchange"})
+
+	MessagesConsumed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "messages_consumed_total",
+		Help:      "Deliveries received by consumers, by queue.",
+	}, []string{"queue"})
+
+	MessagesSettled = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "messages_settled_total",
+		Help:      "Deliveries settled by consumers, by queue and outcome.",
+	}, []string{"queue", "outcome"})
+
+	ProcessingDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "processing_duration_seconds",
+		Help:      "Duration of notification processing, by notification type and result.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"type", "result"})
+
+	Reconnects = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rabbitmq_reconnects_total",
+		Help:      "Attempts to recover the broker connection or channels, by result.",
+	}, []string{"result"})
+
+	HTTPRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests served, by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "Latency of HTTP requests, by method and route template.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+)
+
+// Result maps an error to the result label value.
+func Result(err error) string {
+	if err != nil {
+		return ResultFailure
+	}
+	return ResultSuccess
+}
@@ -0,0 +1,66 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package middlewares
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/spf13/viper"
+	"io"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewAdminAuth(t *testing.T) {
+	tests := []struct {
+		name          string
+		token         string
+		tokens        string
+		authorization string
+		want          int
+		wantAdmin     string
+	}{
+		{name: "disabled", authorization: "Bearer anything", want: fiber.StatusForbidden},
+		{name: "missing token", token: "secret", want: fiber.StatusUnauthorized},
+		{name: "not a bearer token", token: "secret", authorization: "Basic c2VjcmV0", want: fiber.StatusUnauthorized},
+		{name: "wrong token", token: "secret", authorization: "Bearer wrong", want: fiber.StatusForbidden},
+		{name: "ADMIN_API_TOKEN", token: "secret", authorization: "Bearer secret", want: fiber.StatusOK, wantAdmin: "admin"},
+		{name: "ADMIN_API_TOKENS", token: "secret", tokens: "alice:a-token, bob:b-token", authorization: "Bearer b-token", want: fiber.StatusOK, wantAdmin: "bob"},
+		{name: "malformed pairs skipped", tokens: "alice,:x,bob:", authorization: "Bearer x", want: fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set("ADMIN_API_TOKEN", tt.token)
+			viper.Set("ADMIN_API_TOKENS", tt.tokens)
+			t.Cleanup(viper.Reset)
+
+			app := fiber.New()
+			app.Get("/", NewAdminAuth(), func(ctx *fiber.Ctx) error {
+				return ctx.SendString(AdminName(ctx))
+			})
+
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			if tt.authorization != "" {
+				req.Header.Set(fiber.HeaderAuthorization, tt.authorization)
+			}
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+
+			if tt.want == fiber.StatusOK {
+				body, _ := io.ReadAll(resp.Body)
+				if string(body) != tt.wantAdmin {
+					t.Errorf("AdminName = %q, want %q", body, tt.wantAdmin)
+				}
+			}
+		})
+	}
+}
@@ -14,7 +14,7 @@ func NewCORS() fiber.Handler {
 	return cors.New(cors.Config{
 		AllowOrigins:  "*", // or specific origins: "http://localhost:3000, https://yourdomain.com"
 		AllowMethods:  "GET,POST,PUT,DELETE,OPTIONS",
-		AllowHeaders:  "Origin, Content-Type, Accept, Authorization",
-		ExposeHeaders: "Content-Length, Content-Type",
+		AllowHeaders:  "Origin, Content-Type, Accept, Authorization, X-Request-ID",
+		ExposeHeaders: "Content-Length, Content-Type, X-Request-ID",
 	})
 }
@@ -0,0 +1,77 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package middlewares
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/config"
+	"net/url"
+	"strings"
+)
+
+// NewInboxAuth restricts the inbox endpoints to the recipient named by the param path parameter: the
+// bearer token must be the inbox token of that recipient, see InboxToken, which the application issuing
+// its sessions computes with INBOX_TOKEN_SECRET. Admin tokens, see NewAdminAuth, open every inbox. Without
+// INBOX_TOKEN_SECRET only admins can read inboxes.
+func NewInboxAuth(param string) fiber.Handler {
+	secret := []byte(config.GetString("INBOX_TOKEN_SECRET", ""))
+	admins := loadAdminTokens()
+
+	return func(ctx *fiber.Ctx) error {
+		if len(secret) == 0 && len(admins) == 0 {
+			return fiber.NewError(fiber.StatusForbidden, "Inbox endpoints are disabled")
+		}
+
+		provided, ok := strings.CutPrefix(ctx.Get(fiber.HeaderAuthorization), "Bearer ")
+		if !ok || provided == "" {
+			return fiber.NewError(fiber.StatusUnauthorized, "Missing bearer token")
+		}
+
+		if name := matchAdminToken(admins, provided); name != "" {
+			ctx.Locals(localAdmin, name)
+			return ctx.Next()
+		}
+
+		recipient, err := url.PathUnescape(ctx.Params(param))
+		if err != nil {
+			recipient = ctx.Params(param)
+		}
+		if !validInboxToken(secret, recipient, provided) {
+			return fiber.NewError(fiber.StatusForbidden, "Invalid bearer token")
+		}
+
+		return ctx.Next()
+	}
+}
+
+// InboxAuthorized reports whether the request may read the inbox of recipient outside of the inbox
+// endpoints, e.g. by peeking at its queue: it was authenticated as an admin, or it carries the inbox token
+// of recipient.
+func InboxAuthorized(ctx *fiber.Ctx, recipient string) bool {
+	if AdminName(ctx) != "" {
+		return true
+	}
+
+	provided, ok := strings.CutPrefix(ctx.Get(fiber.HeaderAuthorization), "Bearer ")
+	return ok && validInboxToken([]byte(config.GetString("INBOX_TOKEN_SECRET", "")), recipient, provided)
+}
+
+func validInboxToken(secret []byte, recipient, provided string) bool {
+	return len(secret) > 0 && recipient != "" &&
+		subtle.ConstantTimeCompare([]byte(provided), []byte(InboxToken(secret, recipient))) == 1
+}
+
+// InboxToken returns the bearer token giving access to the inbox of recipient: the hex HMAC-SHA256 of the
+// recipient keyed with secret.
+func InboxToken(secret []byte, recipient string) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(recipient))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,112 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package middlewares
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/spf13/viper"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNewInboxAuth(t *testing.T) {
+	secret := []byte("inbox-secret")
+
+	tests := []struct {
+		name          string
+		secret        string
+		adminToken    string
+		path          string
+		authorization string
+		want          int
+	}{
+		{name: "own inbox", secret: string(secret), path: "/inbox/user-42", authorization: "Bearer " + InboxToken(secret, "user-42"), want: fiber.StatusNoContent},
+		{name: "own message", secret: string(secret), path: "/inbox/user-42/n-1", authorization: "Bearer " + InboxToken(secret, "user-42"), want: fiber.StatusNoContent},
+		{name: "escaped recipient", secret: string(secret), path: "/inbox/user%2F42", authorization: "Bearer " + InboxToken(secret, "user/42"), want: fiber.StatusNoContent},
+		{name: "other inbox", secret: string(secret), path: "/inbox/user-43", authorization: "Bearer " + InboxToken(secret, "user-42"), want: fiber.StatusForbidden},
+		{name: "other secret", secret: string(secret), path: "/inbox/user-42", authorization: "Bearer " + InboxToken([]byte("other"), "user-42"), want: fiber.StatusForbidden},
+		{name: "missing token", secret: string(secret), path: "/inbox/user-42", want: fiber.StatusUnauthorized},
+		{name: "admin token", secret: string(secret), adminToken: "admin-token", path: "/inbox/user-43", authorization: "Bearer admin-token", want: fiber.StatusNoContent},
+		{name: "admin only", adminToken: "admin-token", path: "/inbox/user-42", authorization: "Bearer " + InboxToken(nil, "user-42"), want: fiber.StatusForbidden},
+		{name: "disabled", path: "/inbox/user-42", authorization: "Bearer " + InboxToken(nil, "user-42"), want: fiber.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set("INBOX_TOKEN_SECRET", tt.secret)
+			viper.Set("ADMIN_API_TOKEN", tt.adminToken)
+			t.Cleanup(viper.Reset)
+
+			app := fiber.New()
+			inbox := app.Group("/inbox/:recipient", NewInboxAuth("recipient"))
+			inbox.Get("/", func(ctx *fiber.Ctx) error {
+				return ctx.SendStatus(fiber.StatusNoContent)
+			})
+			inbox.Get("/:id", func(ctx *fiber.Ctx) error {
+				return ctx.SendStatus(fiber.StatusNoContent)
+			})
+
+			req := httptest.NewRequest(fiber.MethodGet, tt.path, nil)
+			if tt.authorization != "" {
+				req.Header.Set(fiber.HeaderAuthorization, tt.authorization)
+			}
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.StatusCode != tt.want {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+func TestInboxAuthorized(t *testing.T) {
+	secret := []byte("inbox-secret")
+
+	tests := []struct {
+		name          string
+		admin         string
+		authorization string
+		recipient     string
+		want          bool
+	}{
+		{name: "admin", admin: "alice", recipient: "user-42", want: true},
+		{name: "inbox token", authorization: "Bearer " + InboxToken(secret, "user-42"), recipient: "user-42", want: true},
+		{name: "token of another recipient", authorization: "Bearer " + InboxToken(secret, "user-43"), recipient: "user-42"},
+		{name: "no token", recipient: "user-42"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Set("INBOX_TOKEN_SECRET", string(secret))
+			t.Cleanup(viper.Reset)
+
+			var got bool
+			app := fiber.New()
+			app.Get("/", func(ctx *fiber.Ctx) error {
+				if tt.admin != "" {
+					ctx.Locals(localAdmin, tt.admin)
+				}
+				got = InboxAuthorized(ctx, tt.recipient)
+				return nil
+			})
+
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			if tt.authorization != "" {
+				req.Header.Set(fiber.HeaderAuthorization, tt.authorization)
+			}
+			if _, err := app.Test(req); err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("InboxAuthorized = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
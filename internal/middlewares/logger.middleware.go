@@ -7,16 +7,98 @@ package middlewares
 
 import (
 	"github.com/gofiber/fiber/v2"
-	"github.com/gofiber/fiber/v2/middleware/logger"
-	"os"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/config"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"strings"
+	"sync/atomic"
 	"time"
+	_ "time/tzdata" // the runtime images have no zoneinfo for ACCESS_LOG_TIMEZONE
 )
 
-func NewLogger() fiber.Handler {
-	return logger.New(logger.Config{
-		Format:     "[${time}] ${status} | ${latency} | ${ip} | ${method} ${path} ${queryParams} | ${bytesSent}B ${error}\n",
-		TimeFormat: time.RFC3339,
-		TimeZone:   "Asia/Ho_Chi_Minh",
-		Output:     os.Stdout,
+// NewLogger writes a structured access log line with logger for every request: at error level for 5xx
+// responses, at warn level for 4xx ones and at info level otherwise. Successful requests can be sampled
+// with ACCESS_LOG_SAMPLE_EVERY, only one in every n of them is logged; failed requests are always logged.
+// The time field is formatted with the Go layout ACCESS_LOG_TIME_FORMAT in the ACCESS_LOG_TIMEZONE location.
+// Query parameters are logged without their values, which may hold recipients or tokens.
+// Errors are turned into responses by the error handler after the middleware chain, so bytes is 0 for them.
+func NewLogger(logger *zap.Logger) fiber.Handler {
+	sampleEvery := uint64(max(config.GetInt("ACCESS_LOG_SAMPLE_EVERY", 1), 1))
+	timeFormat := config.GetString("ACCESS_LOG_TIME_FORMAT", time.RFC3339)
+
+	timezone := config.GetString("ACCESS_LOG_TIMEZONE", "UTC")
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		logger.Warn("Unknown access log timezone, using UTC", zap.String("timezone", timezone), zap.Error(err))
+		location = time.UTC
+	}
+
+	// a stack trace of the middleware says nothing about why a request failed
+	logger = logger.Named("access").WithOptions(zap.AddStacktrace(zapcore.FatalLevel))
+
+	var successes atomic.Uint64
+
+	return func(ctx *fiber.Ctx) error {
+		start := time.Now()
+
+		err := ctx.Next()
+
+		latency := time.Since(start)
+		status := responseStatus(ctx, err)
+
+		level := zapcore.InfoLevel
+		switch {
+		case status >= fiber.StatusInternalServerError:
+			level = zapcore.ErrorLevel
+		case status >= fiber.StatusBadRequest:
+			level = zapcore.WarnLevel
+		default:
+			if successes.Add(1)%sampleEvery != 0 {
+				return err
+			}
+		}
+
+		entry := logger.Check(level, "HTTP request")
+		if entry == nil {
+			return err
+		}
+
+		fields := []zap.Field{
+			zap.String("time", start.In(location).Format(timeFormat)),
+			zap.String("method", ctx.Method()),
+			zap.String("route", routePattern(ctx, status)),
+			zap.String("path", ctx.Path()),
+			zap.Int("status", status),
+			zap.Duration("latency", latency),
+			zap.Int("bytes", len(ctx.Response().Body())),
+			zap.String("request_id", ctx.GetRespHeader(fiber.HeaderXRequestID)),
+			zap.String("ip", ctx.IP()),
+		}
+		if query := redactQuery(ctx); query != "" {
+			fields = append(fields, zap.String("query", query))
+		}
+		if admin := AdminName(ctx); admin != "" {
+			fields = append(fields, zap.String("user", admin))
+		}
+		if err != nil {
+			fields = append(fields, zap.Error(err))
+		}
+
+		entry.Write(fields...)
+
+		return err
+	}
+}
+
+// redactQuery returns the query string of the request with every value replaced by a placeholder.
+func redactQuery(ctx *fiber.Ctx) string {
+	var query strings.Builder
+	ctx.Request().URI().QueryArgs().VisitAll(func(key, _ []byte) {
+		if query.Len() > 0 {
+			query.WriteByte('&')
+		}
+		query.Write(key)
+		query.WriteString("=***")
 	})
+	return query.String()
 }
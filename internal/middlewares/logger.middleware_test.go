@@ -0,0 +1,174 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package middlewares
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newLoggedApp returns an app logging its requests with NewLogger into the returned observer.
+func newLoggedApp(t *testing.T) (*fiber.App, *observer.ObservedLogs) {
+	t.Helper()
+
+	core, logs := observer.New(zapcore.DebugLevel)
+
+	app := fiber.New()
+	app.Use(NewLogger(zap.New(core)))
+	app.Get("/ok", func(ctx *fiber.Ctx) error {
+		return ctx.SendString("ok")
+	})
+	app.Get("/queues/:name", func(ctx *fiber.Ctx) error {
+		return ctx.SendString(ctx.Params("name"))
+	})
+	app.Get("/bad", func(ctx *fiber.Ctx) error {
+		return ctx.SendStatus(fiber.StatusBadRequest)
+	})
+	app.Get("/missing", func(ctx *fiber.Ctx) error {
+		return fiber.NewError(fiber.StatusNotFound, "queue not found")
+	})
+	app.Get("/fail", func(ctx *fiber.Ctx) error {
+		return errors.New("broker unreachable")
+	})
+
+	return app, logs
+}
+
+func request(t *testing.T, app *fiber.App, target string) {
+	t.Helper()
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, target, nil))
+	if err != nil {
+		t.Fatalf("request %s: %v", target, err)
+	}
+	_ = resp.Body.Close()
+}
+
+func TestNewLoggerLevels(t *testing.T) {
+	tests := []struct {
+		target     string
+		wantLevel  zapcore.Level
+		wantStatus int64
+		wantRoute  string
+	}{
+		{target: "/ok", wantLevel: zapcore.InfoLevel, wantStatus: fiber.StatusOK, wantRoute: "/ok"},
+		{target: "/queues/queue_notification", wantLevel: zapcore.InfoLevel, wantStatus: fiber.StatusOK, wantRoute: "/queues/:name"},
+		{target: "/bad", wantLevel: zapcore.WarnLevel, wantStatus: fiber.StatusBadRequest, wantRoute: "/bad"},
+		{target: "/missing", wantLevel: zapcore.WarnLevel, wantStatus: fiber.StatusNotFound, wantRoute: "/missing"},
+		{target: "/fail", wantLevel: zapcore.ErrorLevel, wantStatus: fiber.StatusInternalServerError, wantRoute: "/fail"},
+		{target: "/no/such/route", wantLevel: zapcore.WarnLevel, wantStatus: fiber.StatusNotFound, wantRoute: unmatchedRoute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			t.Cleanup(viper.Reset)
+
+			app, logs := newLoggedApp(t)
+			request(t, app, tt.target)
+
+			entries := logs.FilterMessage("HTTP request").All()
+			if len(entries) != 1 {
+				t.Fatalf("logged %d entries, want 1", len(entries))
+			}
+
+			entry := entries[0]
+			fields := entry.ContextMap()
+			if entry.Level != tt.wantLevel || fields["status"] != tt.wantStatus || fields["route"] != tt.wantRoute || fields["path"] != tt.target {
+				t.Errorf("logged %s %v, want %s with status %d on route %s", entry.Level, fields, tt.wantLevel, tt.wantStatus, tt.wantRoute)
+			}
+		})
+	}
+}
+
+func TestNewLoggerSampling(t *testing.T) {
+	viper.Set("ACCESS_LOG_SAMPLE_EVERY", 3)
+	t.Cleanup(viper.Reset)
+
+	app, logs := newLoggedApp(t)
+
+	for i := 0; i < 6; i++ {
+		request(t, app, "/ok")
+		request(t, app, "/bad")
+		request(t, app, "/fail")
+	}
+
+	counts := map[zapcore.Level]int{}
+	for _, entry := range logs.FilterMessage("HTTP request").All() {
+		counts[entry.Level]++
+	}
+
+	// one in three successes, but every failure
+	if counts[zapcore.InfoLevel] != 2 || counts[zapcore.WarnLevel] != 6 || counts[zapcore.ErrorLevel] != 6 {
+		t.Errorf("logged %v entries by level, want 2 info, 6 warn and 6 error", counts)
+	}
+}
+
+func TestNewLoggerTimezone(t *testing.T) {
+	tests := []struct {
+		name       string
+		timezone   string
+		wantOffset string
+		wantWarn   bool
+	}{
+		{name: "default", wantOffset: "Z"},
+		{name: "known", timezone: "Asia/Ho_Chi_Minh", wantOffset: "+07:00"},
+		{name: "unknown falls back to UTC", timezone: "Mars/Olympus_Mons", wantOffset: "Z", wantWarn: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.timezone != "" {
+				viper.Set("ACCESS_LOG_TIMEZONE", tt.timezone)
+			}
+			t.Cleanup(viper.Reset)
+
+			app, logs := newLoggedApp(t)
+			request(t, app, "/ok")
+
+			if warned := logs.FilterMessage("Unknown access log timezone, using UTC").Len() == 1; warned != tt.wantWarn {
+				t.Errorf("warned about the timezone: %v, want %v", warned, tt.wantWarn)
+			}
+
+			entries := logs.FilterMessage("HTTP request").All()
+			if len(entries) != 1 {
+				t.Fatalf("logged %d entries, want 1", len(entries))
+			}
+
+			logged, _ := entries[0].ContextMap()["time"].(string)
+			if _, err := time.Parse(time.RFC3339, logged); err != nil || !strings.HasSuffix(logged, tt.wantOffset) {
+				t.Errorf("time = %q, want RFC 3339 ending with %s", logged, tt.wantOffset)
+			}
+		})
+	}
+}
+
+func TestNewLoggerRedactsQuery(t *testing.T) {
+	t.Cleanup(viper.Reset)
+
+	app, logs := newLoggedApp(t)
+	request(t, app, "/ok?recipient=kiet%40example.com&token=secret&limit=10")
+	request(t, app, "/ok")
+
+	entries := logs.FilterMessage("HTTP request").All()
+	if len(entries) != 2 {
+		t.Fatalf("logged %d entries, want 2", len(entries))
+	}
+
+	if got := entries[0].ContextMap()["query"]; got != "recipient=***&token=***&limit=***" {
+		t.Errorf("query = %v, want the parameter names only", got)
+	}
+	if _, ok := entries[1].ContextMap()["query"]; ok {
+		t.Error("query logged for a request without one")
+	}
+}
@@ -0,0 +1,31 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package middlewares
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/metrics"
+	"strconv"
+	"time"
+)
+
+// NewMetrics records the count and latency of HTTP requests. Requests are labelled with the route template
+// rather than the path, so that path parameters don't create a time series per value.
+func NewMetrics() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		start := time.Now()
+
+		err := ctx.Next()
+
+		status := responseStatus(ctx, err)
+		route := routePattern(ctx, status)
+
+		metrics.HTTPRequests.WithLabelValues(ctx.Method(), route, strconv.Itoa(status)).Inc()
+		metrics.HTTPRequestDuration.WithLabelValues(ctx.Method(), route).Observe(time.Since(start).Seconds())
+
+		return err
+	}
+}
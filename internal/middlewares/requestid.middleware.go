@@ -0,0 +1,48 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package middlewares
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
+	"github.com/google/uuid"
+)
+
+// maxRequestIDLength bounds a client-supplied request ID, which becomes the AMQP correlation ID, a short
+// string of at most 255 bytes, and is logged as is.
+const maxRequestIDLength = 128
+
+// NewRequestID keeps the X-Request-ID of the request, or generates one when it is missing or isn't 1 to
+// maxRequestIDLength printable ASCII characters, and echoes it in the response header and the "requestid"
+// local.
+func NewRequestID() fiber.Handler {
+	handler := requestid.New(requestid.Config{
+		Header:    fiber.HeaderXRequestID,
+		Generator: uuid.NewString,
+	})
+
+	return func(ctx *fiber.Ctx) error {
+		if id := ctx.Get(fiber.HeaderXRequestID); id != "" && !validRequestID(id) {
+			ctx.Request().Header.Del(fiber.HeaderXRequestID)
+		}
+
+		return handler(ctx)
+	}
+}
+
+func validRequestID(id string) bool {
+	if len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x20 || id[i] > 0x7e {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,57 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package middlewares
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestNewRequestID(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		kept bool
+	}{
+		{name: "missing", id: "", kept: false},
+		{name: "uuid", id: "0b5a3f9e-2f7c-4c1e-9d3a-6a1b2c3d4e5f", kept: true},
+		{name: "printable", id: "req 42/abc:def", kept: true},
+		{name: "max length", id: strings.Repeat("a", maxRequestIDLength), kept: true},
+		{name: "too long", id: strings.Repeat("a", maxRequestIDLength+1), kept: false},
+		{name: "control character", id: "abc\x01def", kept: false},
+		{name: "non-ASCII", id: "réquest", kept: false},
+	}
+
+	app := fiber.New()
+	app.Use(NewRequestID())
+	app.Get("/", func(ctx *fiber.Ctx) error {
+		return ctx.SendStatus(fiber.StatusNoContent)
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			if tt.id != "" {
+				req.Header.Set(fiber.HeaderXRequestID, tt.id)
+			}
+
+			resp, err := app.Test(req)
+			if err != nil {
+				t.Fatalf("request: %v", err)
+			}
+
+			got := resp.Header.Get(fiber.HeaderXRequestID)
+			switch {
+			case tt.kept && got != tt.id:
+				t.Errorf("request ID = %q, want %q", got, tt.id)
+			case !tt.kept && (got == tt.id || len(got) != 36):
+				t.Errorf("request ID = %q, want a generated UUID", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,39 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package middlewares
+
+import (
+	"errors"
+	"github.com/gofiber/fiber/v2"
+)
+
+// unmatchedRoute is the route reported for requests that matched no route.
+const unmatchedRoute = "unmatched"
+
+// responseStatus returns the status the response will be sent with. The error handler only runs after the
+// middleware chain, so for a failed request the status is taken from the error.
+func responseStatus(ctx *fiber.Ctx, err error) int {
+	var fiberErr *fiber.Error
+	switch {
+	case errors.As(err, &fiberErr):
+		return fiberErr.Code
+	case err != nil:
+		return fiber.StatusInternalServerError
+	default:
+		return ctx.Response().StatusCode()
+	}
+}
+
+// routePattern returns the template of the route that handled the request, e.g. /api/v1/queues/:name,
+// rather than its path. It must be called after ctx.Next().
+func routePattern(ctx *fiber.Ctx, status int) string {
+	route := ctx.Route().Path
+	if status == fiber.StatusNotFound && route == "/" {
+		// no route matched, only the middleware mounted on / did
+		return unmatchedRoute
+	}
+	return route
+}
@@ -0,0 +1,58 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package middlewares
+
+import (
+	"fmt"
+	"github.com/gofiber/fiber/v2"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/tracing"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+	"net/http"
+)
+
+// NewTracing starts a server span for every request, continuing the trace of the caller when the request
+// carries W3C trace context, and makes it the parent of the spans started from ctx.UserContext().
+func NewTracing() fiber.Handler {
+	return func(ctx *fiber.Ctx) error {
+		headers := http.Header{}
+		for key, values := range ctx.GetReqHeaders() {
+			headers[key] = values
+		}
+
+		parent := otel.GetTextMapPropagator().Extract(ctx.UserContext(), propagation.HeaderCarrier(headers))
+
+		spanCtx, span := tracing.Tracer().Start(parent, ctx.Method(),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.request.method", ctx.Method()),
+				attribute.String("url.path", ctx.Path()),
+			),
+		)
+		defer span.End()
+
+		ctx.SetUserContext(spanCtx)
+
+		err := ctx.Next()
+
+		status := responseStatus(ctx, err)
+		route := routePattern(ctx, status)
+
+		span.SetName(fmt.Sprintf("%s %s", ctx.Method(), route))
+		span.SetAttributes(
+			attribute.String("http.route", route),
+			attribute.Int("http.response.status_code", status),
+		)
+		if status >= fiber.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+
+		return err
+	}
+}
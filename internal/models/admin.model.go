@@ -0,0 +1,57 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package models
+
+type Consumer struct {
+	ConsumerTag   string `json:"consumer_tag"`
+	AckRequired   bool   `json:"ack_required"`
+	PrefetchCount int    `json:"prefetch_count"`
+	Active        bool   `json:"active"`
+	Channel       string `json:"channel"`
+	Connection    string `json:"connection"`
+	PeerHost      string `json:"peer_host"`
+}
+
+type QueueDetails struct {
+	Queue
+	Node                       string     `json:"node"`
+	Memory                     int64      `json:"memory"` // Bytes used by the queue process
+	MessageBytes               int64      `json:"message_bytes"`
+	MessageBytesReady          int64      `json:"message_bytes_ready"`
+	MessageBytesUnacknowledged int64      `json:"message_bytes_unacknowledged"`
+	ConsumerUtilisation        float64    `json:"consumer_utilisation"` // Fraction of time the queue can deliver to its consumers immediately
+	IdleSince                  string     `json:"idle_since,omitempty"`
+	ConsumerDetails            []Consumer `json:"consumer_details"`
+	Bindings                   []Binding  `json:"bindings"` // Bindings with this queue as the destination
+}
+
+type CreateQueueRequest struct {
+	Type       string         `json:"type,omitempty"`    // classic (default), quorum or stream
+	Durable    *bool          `json:"durable,omitempty"` // Defaults to true
+	AutoDelete bool           `json:"auto_delete"`
+	Arguments  map[string]any `json:"arguments,omitempty"`
+}
+
+type CreateExchangeRequest struct {
+	Type       string         `json:"type"`              // direct, fanout, topic or headers
+	Durable    *bool          `json:"durable,omitempty"` // Defaults to true
+	AutoDelete bool           `json:"auto_delete"`
+	Internal   bool           `json:"internal"`
+	Arguments  map[string]any `json:"arguments,omitempty"`
+}
+
+type CreateBindingRequest struct {
+	Source          string         `json:"source"`
+	Destination     string         `json:"destination"`
+	DestinationType string         `json:"destination_type"` // queue or exchange
+	RoutingKey      string         `json:"routing_key"`
+	Arguments       map[string]any `json:"arguments,omitempty"`
+}
+
+type PurgeQueueResponse struct {
+	Queue  string `json:"queue"`
+	Purged int    `json:"purged"`
+}
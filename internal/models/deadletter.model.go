@@ -0,0 +1,48 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package models
+
+import "time"
+
+type DeadLetterDeath struct {
+	Queue       string     `json:"queue"`
+	Exchange    string     `json:"exchange"`
+	RoutingKeys []string   `json:"routing_keys,omitempty"`
+	Reason      string     `json:"reason"`
+	Count       int64      `json:"count"`
+	Time        *time.Time `json:"time,omitempty"`
+}
+
+type DeadLetterMessage struct {
+	MessageID    string            `json:"message_id"`
+	Queue        string            `json:"queue"`
+	Notification *Notification     `json:"notification,omitempty"`
+	Body         string            `json:"body,omitempty"`         // Raw body when it can't be decoded as a notification
+	BodyOmitted  bool              `json:"body_omitted,omitempty"` // Whether a JSON body that couldn't be decoded, and so masked, was left out
+	Headers      map[string]any    `json:"headers,omitempty"`
+	Deaths       []DeadLetterDeath `json:"deaths,omitempty"`
+	LastError    string            `json:"last_error,omitempty"`
+	RetryAttempt int               `json:"retry_attempt"`
+	PublishedAt  *time.Time        `json:"published_at,omitempty"`
+}
+
+type ReplayDeadLettersRequest struct {
+	Queue      string                   `json:"queue,omitempty"` // Defaults to the notification dead-letter queue
+	MessageIDs []string                 `json:"message_ids"`
+	Overrides  map[string]*Notification `json:"overrides,omitempty"` // Edited payloads by message ID
+}
+
+type ReplayDeadLettersResponse struct {
+	Replayed   []string  `json:"replayed"`
+	NotFound   []string  `json:"not_found,omitempty"`
+	ReplayedBy string    `json:"replayed_by"`
+	ReplayedAt time.Time `json:"replayed_at"`
+}
+
+type PurgeDeadLettersResponse struct {
+	Queue  string `json:"queue"`
+	Purged int    `json:"purged"`
+}
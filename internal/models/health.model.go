@@ -0,0 +1,27 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package models
+
+import "time"
+
+type HealthStatus string
+
+const (
+	HealthStatusUp   HealthStatus = "up"
+	HealthStatusDown HealthStatus = "down"
+)
+
+type HealthCheckResult struct {
+	Status     HealthStatus `json:"status"`
+	Error      string       `json:"error,omitempty"`
+	DurationMs int64        `json:"duration_ms"`
+}
+
+type HealthReport struct {
+	Status    HealthStatus                 `json:"status"`
+	Checks    map[string]HealthCheckResult `json:"checks,omitempty"`
+	Timestamp time.Time                    `json:"timestamp"`
+}
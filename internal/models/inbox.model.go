@@ -0,0 +1,21 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package models
+
+import "time"
+
+type InboxMessage struct {
+	ID         string                 `json:"id"`
+	Title      string                 `json:"title"`
+	Message    string                 `json:"message"`
+	MetaData   map[string]interface{} `json:"meta_data,omitempty"`
+	ReceivedAt *time.Time             `json:"received_at,omitempty"`
+}
+
+type InboxResponse struct {
+	Recipient string          `json:"recipient"`
+	Messages  []*InboxMessage `json:"messages"`
+}
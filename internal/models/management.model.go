@@ -0,0 +1,111 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package models
+
+import "time"
+
+// The types below are the API's own view of broker objects. They are mapped from the RabbitMQ management
+// API rather than passed through, so that their JSON stays stable across RabbitMQ versions.
+
+type QueueMessageRates struct {
+	Publish   float64 `json:"publish"`   // Messages per second published to the queue
+	Deliver   float64 `json:"deliver"`   // Messages per second delivered to consumers or fetched with basic.get
+	Ack       float64 `json:"ack"`       // Messages per second acknowledged by consumers
+	Redeliver float64 `json:"redeliver"` // Messages per second delivered again after a requeue
+}
+
+type Queue struct {
+	Name                   string            `json:"name"`
+	VHost                  string            `json:"vhost"`
+	Type                   string            `json:"type"` // classic, quorum or stream
+	State                  string            `json:"state"`
+	Durable                bool              `json:"durable"`
+	AutoDelete             bool              `json:"auto_delete"`
+	Exclusive              bool              `json:"exclusive"`
+	Messages               int64             `json:"messages"`
+	MessagesReady          int64             `json:"messages_ready"`
+	MessagesUnacknowledged int64             `json:"messages_unacknowledged"`
+	Consumers              int               `json:"consumers"`
+	MessageRates           QueueMessageRates `json:"message_rates"`
+	Arguments              map[string]any    `json:"arguments"`
+	Policy                 string            `json:"policy,omitempty"`
+}
+
+type ExchangeMessageRates struct {
+	PublishIn  float64 `json:"publish_in"`  // Messages per second published to the exchange
+	PublishOut float64 `json:"publish_out"` // Messages per second routed to queues and exchanges
+}
+
+type Exchange struct {
+	Name         string               `json:"name"` // Empty for the default exchange
+	VHost        string               `json:"vhost"`
+	Type         string               `json:"type"`
+	Durable      bool                 `json:"durable"`
+	AutoDelete   bool                 `json:"auto_delete"`
+	Internal     bool                 `json:"internal"`
+	MessageRates ExchangeMessageRates `json:"message_rates"`
+	Arguments    map[string]any       `json:"arguments"`
+	Policy       string               `json:"policy,omitempty"`
+	Bindings     []Binding            `json:"bindings"` // Bindings with this exchange as the source
+}
+
+type Binding struct {
+	Source          string         `json:"source"`
+	Destination     string         `json:"destination"`
+	DestinationType string         `json:"destination_type"` // queue or exchange
+	VHost           string         `json:"vhost"`
+	RoutingKey      string         `json:"routing_key"`
+	Arguments       map[string]any `json:"arguments"`
+	PropertiesKey   string         `json:"properties_key"` // Identifies the binding among those between the same source and destination
+}
+
+type Paging struct {
+	Page          int `json:"page"`
+	PageSize      int `json:"page_size"`
+	PageCount     int `json:"page_count"`
+	ItemCount     int `json:"item_count"`     // Items on this page
+	FilteredCount int `json:"filtered_count"` // Items matching the name filter
+	TotalCount    int `json:"total_count"`    // Items in the vhost
+}
+
+type QueueList struct {
+	Items  []*Queue `json:"items"`
+	Paging Paging   `json:"paging"`
+}
+
+type ExchangeList struct {
+	Items  []*Exchange `json:"items"`
+	Paging Paging      `json:"paging"`
+}
+
+type MessageProperties struct {
+	ContentType     string     `json:"content_type,omitempty"`
+	ContentEncoding string     `json:"content_encoding,omitempty"`
+	DeliveryMode    int        `json:"delivery_mode,omitempty"` // 1 transient, 2 persistent
+	Priority        int        `json:"priority,omitempty"`
+	CorrelationID   string     `json:"correlation_id,omitempty"`
+	ReplyTo         string     `json:"reply_to,omitempty"`
+	Expiration      string     `json:"expiration,omitempty"`
+	MessageID       string     `json:"message_id,omitempty"`
+	Timestamp       *time.Time `json:"timestamp,omitempty"`
+	Type            string     `json:"type,omitempty"`
+	UserID          string     `json:"user_id,omitempty"`
+	AppID           string     `json:"app_id,omitempty"`
+}
+
+type QueueMessage struct {
+	Exchange     string            `json:"exchange"`
+	RoutingKey   string            `json:"routing_key"`
+	Redelivered  bool              `json:"redelivered"`
+	Properties   MessageProperties `json:"properties"`
+	Headers      map[string]any    `json:"headers,omitempty"`
+	Notification *Notification     `json:"notification,omitempty"`  // Decoded JSON body, with sensitive fields masked
+	Body         string            `json:"body,omitempty"`          // Raw body when it isn't a notification
+	BodyEncoding string            `json:"body_encoding,omitempty"` // string or base64, for the raw body
+	BodyOmitted  bool              `json:"body_omitted,omitempty"`  // Whether a JSON body that couldn't be decoded, and so masked, was left out
+	BodyBytes    int               `json:"body_bytes"`
+	Truncated    bool              `json:"truncated"` // Whether the body was cut off before decoding
+}
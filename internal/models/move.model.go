@@ -0,0 +1,53 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package models
+
+import "time"
+
+type MoveJobStatus string
+
+const (
+	MoveJobStatusRunning   MoveJobStatus = "running"
+	MoveJobStatusCompleted MoveJobStatus = "completed"
+	MoveJobStatusCancelled MoveJobStatus = "cancelled"
+	MoveJobStatusFailed    MoveJobStatus = "failed"
+)
+
+// MoveFilter selects the messages to move, a message must match every condition. Messages that don't match
+// stay in the source queue in their original order.
+type MoveFilter struct {
+	Headers map[string]string `json:"headers,omitempty"` // Header values, compared as strings
+	Fields  map[string]any    `json:"fields,omitempty"`  // JSON body values by dot-separated path, e.g. type or meta_data.tenant
+}
+
+type MoveMessagesRequest struct {
+	TargetExchange   string      `json:"target_exchange"` // Empty for the default exchange, which routes to the queue named by the routing key
+	TargetRoutingKey string      `json:"target_routing_key"`
+	MaxMessages      int         `json:"max_messages,omitempty"`    // Stop after moving this many messages, defaults to no limit
+	RatePerSecond    int         `json:"rate_per_second,omitempty"` // Defaults to no limit
+	Filter           *MoveFilter `json:"filter,omitempty"`
+	ResetRetries     bool        `json:"reset_retries"` // Drop the retry headers so that moved messages get a fresh retry budget
+}
+
+type MoveJob struct {
+	ID               string        `json:"id"`
+	Status           MoveJobStatus `json:"status"`
+	SourceQueue      string        `json:"source_queue"`
+	TargetExchange   string        `json:"target_exchange"`
+	TargetRoutingKey string        `json:"target_routing_key"`
+	MaxMessages      int           `json:"max_messages,omitempty"`
+	RatePerSecond    int           `json:"rate_per_second,omitempty"`
+	Filter           *MoveFilter   `json:"filter,omitempty"`
+	Scanned          int           `json:"scanned"` // Messages fetched from the source queue
+	Moved            int           `json:"moved"`
+	Skipped          int           `json:"skipped"`               // Messages left in place by the filter
+	StopReason       string        `json:"stop_reason,omitempty"` // Why a finished job stopped
+	Error            string        `json:"error,omitempty"`
+	StartedBy        string        `json:"started_by"`
+	StartedAt        time.Time     `json:"started_at"`
+	UpdatedAt        time.Time     `json:"updated_at"`
+	FinishedAt       *time.Time    `json:"finished_at,omitempty"`
+}
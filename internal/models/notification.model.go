@@ -10,9 +10,12 @@ import "time"
 type NotificationType string
 
 const (
-	NotificationTypeEmail NotificationType = "email"
-	NotificationTypeSMS   NotificationType = "sms"
-	NotificationTypePush  NotificationType = "push"
+	NotificationTypeEmail   NotificationType = "email"
+	NotificationTypeSMS     NotificationType = "sms"
+	NotificationTypePush    NotificationType = "push"
+	NotificationTypeWebhook NotificationType = "webhook"
+	NotificationTypeSlack   NotificationType = "slack"
+	NotificationTypeInApp   NotificationType = "in_app"
 )
 
 type NotificationStatus string
@@ -25,26 +28,32 @@ const (
 )
 
 type Notification struct {
-	ID        string                 `json:"id"`
-	Type      NotificationType       `json:"type"`
-	Recipient string                 `json:"recipient"`
-	Title     string                 `json:"title"`
-	Message   string                 `json:"message"`
-	MetaData  map[string]interface{} `json:"meta_data,omitempty"` // Optional metadata for additional information
-	Status    NotificationStatus     `json:"status"`
-	SentAt    *time.Time             `json:"sent_at,omitempty"`
-	Error     string                 `json:"error,omitempty"` // Optional error message if the notification fails
+	ID         string                 `json:"id"`
+	Type       NotificationType       `json:"type"`
+	Recipient  string                 `json:"recipient" sensitive:"true"`
+	Title      string                 `json:"title"`
+	Message    string                 `json:"message"`
+	MetaData   map[string]interface{} `json:"meta_data,omitempty" sensitive:"true"` // Optional metadata for additional information
+	TemplateID string                 `json:"template_id,omitempty"`                // Template the title and message were rendered from
+	Locale     string                 `json:"locale,omitempty"`
+	Status     NotificationStatus     `json:"status"`
+	SentAt     *time.Time             `json:"sent_at,omitempty"`
+	Error      string                 `json:"error,omitempty"` // Optional error message if the notification fails
 }
 
 type SendNotificationRequest struct {
-	Type      NotificationType       `json:"type"`
-	Recipient string                 `json:"recipient"`
-	Title     string                 `json:"title"`
-	Message   string                 `json:"message"`
-	MetaData  map[string]interface{} `json:"meta_data,omitempty"` // Optional metadata for additional information
+	Type       NotificationType       `json:"type"`
+	Recipient  string                 `json:"recipient"`
+	Title      string                 `json:"title"`
+	Message    string                 `json:"message"`
+	MetaData   map[string]interface{} `json:"meta_data,omitempty"`   // Optional metadata for additional information
+	TemplateID string                 `json:"template_id,omitempty"` // Template to render the title and message from, instead of message
+	Locale     string                 `json:"locale,omitempty"`      // Locale of the template, defaults to TEMPLATE_DEFAULT_LOCALE
+	Variables  map[string]interface{} `json:"variables,omitempty"`   // Template variables, in addition to the metadata
 }
 
 type SendNotificationResponse struct {
-	ID     string             `json:"id"`
-	Status NotificationStatus `json:"status"`
+	ID        string             `json:"id"`
+	Status    NotificationStatus `json:"status"`
+	RequestID string             `json:"request_id"`
 }
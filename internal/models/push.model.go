@@ -0,0 +1,33 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package models
+
+import "time"
+
+type PushPlatform string
+
+const (
+	PushPlatformAndroid PushPlatform = "android"
+	PushPlatformIOS     PushPlatform = "ios"
+)
+
+type PushPriority string
+
+const (
+	PushPriorityHigh   PushPriority = "high"
+	PushPriorityNormal PushPriority = "normal"
+)
+
+// TokenInvalidatedEvent is published when a push service reports a device token as no longer valid, e.g.
+// because the app was uninstalled, so that the owner of the token can stop sending to it.
+type TokenInvalidatedEvent struct {
+	Token          string       `json:"token"`
+	Platform       PushPlatform `json:"platform"`
+	Provider       string       `json:"provider"`
+	Reason         string       `json:"reason"`
+	NotificationID string       `json:"notification_id"`
+	InvalidatedAt  time.Time    `json:"invalidated_at"`
+}
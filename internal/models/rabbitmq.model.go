@@ -5,21 +5,59 @@
 
 package models
 
+type Topology struct {
+	Exchanges []*ExchangeConfig `json:"exchanges" yaml:"exchanges"`
+	Queues    []*QueueConfig    `json:"queues" yaml:"queues"`
+	Bindings  []*BindingConfig  `json:"bindings" yaml:"bindings"`
+	Policies  []*PolicyConfig   `json:"policies,omitempty" yaml:"policies"`
+}
+
 type ExchangeConfig struct {
-	Name       string `json:"name"`
-	Type       string `json:"type"`
-	Durable    bool   `json:"durable"`
-	AutoDelete bool   `json:"auto_delete"`
+	Name              string         `json:"name" yaml:"name"`
+	Type              string         `json:"type" yaml:"type"`
+	Durable           bool           `json:"durable" yaml:"durable"`
+	AutoDelete        bool           `json:"auto_delete" yaml:"auto_delete"`
+	Internal          bool           `json:"internal" yaml:"internal"`
+	AlternateExchange string         `json:"alternate_exchange,omitempty" yaml:"alternate_exchange"`
+	Args              map[string]any `json:"args,omitempty" yaml:"args"`
 }
 
 type QueueConfig struct {
-	Name       string `json:"name"`
-	Durable    bool   `json:"durable"`
-	AutoDelete bool   `json:"auto_delete"`
+	Name       string         `json:"name" yaml:"name"`
+	Durable    bool           `json:"durable" yaml:"durable"`
+	AutoDelete bool           `json:"auto_delete" yaml:"auto_delete"`
+	Exclusive  bool           `json:"exclusive" yaml:"exclusive"`
+	Args       map[string]any `json:"args,omitempty" yaml:"args"`
 }
 
+// BindingConfig binds Exchange to either a Queue or, for exchange-to-exchange bindings, a DestinationExchange.
 type BindingConfig struct {
-	Queue      string `json:"queue"`
-	Exchange   string `json:"exchange"`
-	RoutingKey string `json:"routing_key"`
+	Queue               string         `json:"queue,omitempty" yaml:"queue"`
+	DestinationExchange string         `json:"destination_exchange,omitempty" yaml:"destination_exchange"`
+	Exchange            string         `json:"exchange" yaml:"exchange"`
+	RoutingKey          string         `json:"routing_key" yaml:"routing_key"`
+	Args                map[string]any `json:"args,omitempty" yaml:"args"` // e.g. x-match and header values for headers exchanges
+}
+
+// PolicyConfig is a broker policy, applied through the management API. Unlike queue arguments, the
+// definition of a policy can change on a queue that already exists.
+type PolicyConfig struct {
+	Name       string         `json:"name" yaml:"name"`
+	Pattern    string         `json:"pattern" yaml:"pattern"`   // regular expression matched against queue or exchange names
+	ApplyTo    string         `json:"apply_to" yaml:"apply_to"` // queues, exchanges or all, queues by default
+	Priority   int            `json:"priority" yaml:"priority"`
+	Definition map[string]any `json:"definition" yaml:"definition"` // e.g. dead-letter-exchange
+}
+
+type TopologyDrift struct {
+	Kind    string `json:"kind"` // exchange, queue, binding or policy
+	Name    string `json:"name"`
+	Field   string `json:"field"` // "missing" when the broker doesn't have the object at all
+	Desired any    `json:"desired,omitempty"`
+	Actual  any    `json:"actual,omitempty"`
+}
+
+type TopologyVerification struct {
+	InSync bool            `json:"in_sync"`
+	Drifts []TopologyDrift `json:"drifts"`
 }
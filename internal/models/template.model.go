@@ -0,0 +1,47 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package models
+
+// TemplateContent is a template in one locale. Title and Text are text/template templates and HTML, the HTML
+// body of emails, an html/template one, all rendered with the variables of the notification.
+type TemplateContent struct {
+	Title    string                                `json:"title" yaml:"title"`
+	Text     string                                `json:"text" yaml:"text"`
+	HTML     string                                `json:"html,omitempty" yaml:"html,omitempty"`
+	Channels map[NotificationType]*TemplateVariant `json:"channels,omitempty" yaml:"channels,omitempty"` // Per-channel overrides, e.g. a shorter text for sms
+}
+
+// TemplateVariant overrides the fields of a TemplateContent that it sets for one channel.
+type TemplateVariant struct {
+	Title string `json:"title,omitempty" yaml:"title,omitempty"`
+	Text  string `json:"text,omitempty" yaml:"text,omitempty"`
+	HTML  string `json:"html,omitempty" yaml:"html,omitempty"`
+}
+
+type TemplateSummary struct {
+	ID      string   `json:"id"`
+	Locales []string `json:"locales"`
+}
+
+type TemplateResponse struct {
+	ID      string                      `json:"id"`
+	Locales map[string]*TemplateContent `json:"locales"`
+}
+
+type PreviewTemplateRequest struct {
+	Type      NotificationType       `json:"type,omitempty"`      // Channel whose variant is rendered, the default content when empty
+	Locale    string                 `json:"locale,omitempty"`    // Defaults to TEMPLATE_DEFAULT_LOCALE
+	MetaData  map[string]interface{} `json:"meta_data,omitempty"` // Metadata of the notification, also available to the template
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+type RenderedTemplate struct {
+	TemplateID string `json:"template_id"`
+	Locale     string `json:"locale"` // Locale the template was found in, after falling back
+	Title      string `json:"title"`
+	Message    string `json:"message"`
+	HTML       string `json:"html,omitempty"`
+}
@@ -0,0 +1,217 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package providers
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/config"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/services"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// apnsTokenLifetime is how long a provider token is used. APNs rejects tokens older than an hour, and
+// refreshing more often than every 20 minutes.
+const apnsTokenLifetime = 50 * time.Minute
+
+// APNsGateway sends push notifications through the APNs provider API over HTTP/2, authenticating with
+// provider tokens signed by the .p8 key PUSH_APNS_KEY_FILE of PUSH_APNS_KEY_ID and team PUSH_APNS_TEAM_ID.
+// Notifications are sent to the app PUSH_APNS_TOPIC, its bundle ID. PUSH_APNS_BASE_URL overrides the
+// endpoint, e.g. with https://api.sandbox.push.apple.com or a local stub.
+type APNsGateway struct {
+	baseURL string
+	topic   string
+	keyID   string
+	teamID  string
+	key     *ecdsa.PrivateKey
+	client  *http.Client
+
+	mu       sync.Mutex
+	token    string
+	issuedAt time.Time
+}
+
+func NewAPNsGateway() (*APNsGateway, error) {
+	g := &APNsGateway{
+		baseURL: strings.TrimSuffix(config.GetString("PUSH_APNS_BASE_URL", "https://api.push.apple.com"), "/"),
+		topic:   config.GetString("PUSH_APNS_TOPIC", ""),
+		keyID:   config.GetString("PUSH_APNS_KEY_ID", ""),
+		teamID:  config.GetString("PUSH_APNS_TEAM_ID", ""),
+		client: &http.Client{
+			Timeout: time.Duration(config.GetInt("PUSH_TIMEOUT_MS", 10000)) * time.Millisecond,
+			Transport: &http.Transport{
+				Proxy:             http.ProxyFromEnvironment,
+				ForceAttemptHTTP2: true,
+				TLSClientConfig:   &tls.Config{MinVersion: tls.VersionTLS12},
+				IdleConnTimeout:   time.Hour,
+			},
+		},
+	}
+	if g.topic == "" || g.keyID == "" || g.teamID == "" {
+		return nil, errors.New("PUSH_APNS_TOPIC, PUSH_APNS_KEY_ID and PUSH_APNS_TEAM_ID are required")
+	}
+
+	data, err := os.ReadFile(config.GetString("PUSH_APNS_KEY_FILE", ""))
+	if err != nil {
+		return nil, fmt.Errorf("read APNs key: %w", err)
+	}
+
+	key, err := parsePrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("APNs key: %w", err)
+	}
+
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("APNs key: expected an ECDSA key, got %T", key)
+	}
+	g.key = ecKey
+
+	return g, nil
+}
+
+func (g *APNsGateway) Name() string {
+	return "apns"
+}
+
+// SendPush sends an alert notification to the device token and returns its apns-id. The reasons
+// BadDeviceToken, DeviceTokenNotForTopic and Unregistered invalidate the token; an expired or invalid
+// provider token, the topic, rate limits and failures of the service are retryable, other 4xx permanent.
+func (g *APNsGateway) SendPush(ctx context.Context, token string, push *Push) (string, error) {
+	providerToken, err := g.providerToken()
+	if err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(apnsPayload(push))
+	if err != nil {
+		return "", services.Permanent(fmt.Errorf("apns: encode payload: %w", err))
+	}
+
+	target := fmt.Sprintf("%s/3/device/%s", g.baseURL, url.PathEscape(token))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("apns: create request: %w", err)
+	}
+	req.Header.Set("Authorization", "bearer "+providerToken)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("apns-topic", g.topic)
+	req.Header.Set("apns-push-type", "alert")
+	if push.Priority == models.PushPriorityNormal {
+		req.Header.Set("apns-priority", "5")
+	} else {
+		req.Header.Set("apns-priority", "10")
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return "", services.Retryable(fmt.Errorf("apns: %w", err))
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 300 {
+		var reply struct {
+			Reason string `json:"reason"`
+		}
+		_ = json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&reply)
+
+		return "", g.apnsError(resp.StatusCode, reply.Reason)
+	}
+
+	return resp.Header.Get("apns-id"), nil
+}
+
+func apnsPayload(push *Push) map[string]interface{} {
+	aps := map[string]interface{}{
+		"alert": map[string]string{
+			"title": push.Title,
+			"body":  push.Body,
+		},
+	}
+	if push.Badge != nil {
+		aps["badge"] = *push.Badge
+	}
+	if push.Sound != "" {
+		aps["sound"] = push.Sound
+	}
+
+	payload := map[string]interface{}{}
+	for k, v := range push.Data {
+		payload[k] = v
+	}
+	// set last, the data can't override it
+	payload["aps"] = aps
+
+	return payload
+}
+
+func (g *APNsGateway) apnsError(status int, reason string) error {
+	if reason == "" {
+		reason = http.StatusText(status)
+	}
+	err := fmt.Errorf("apns: %d %s", status, reason)
+
+	switch reason {
+	case "BadDeviceToken", "DeviceTokenNotForTopic", "Unregistered":
+		return &TokenInvalidError{Reason: reason}
+	case "ExpiredProviderToken", "InvalidProviderToken":
+		g.resetToken()
+		return services.Retryable(err)
+	case "MissingTopic", "BadTopic", "TopicDisallowed", "MissingProviderToken", "Forbidden", "TooManyProviderTokenUpdates":
+		return services.Retryable(err)
+	}
+	if status == http.StatusGone {
+		return &TokenInvalidError{Reason: reason}
+	}
+	if status >= 500 || status == http.StatusTooManyRequests {
+		return services.Retryable(err)
+	}
+	return services.Permanent(err)
+}
+
+func (g *APNsGateway) providerToken() (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.token != "" && time.Since(g.issuedAt) < apnsTokenLifetime {
+		return g.token, nil
+	}
+
+	now := time.Now()
+	token, err := signJWT(g.key, g.keyID, map[string]interface{}{
+		"iss": g.teamID,
+		"iat": now.Unix(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("apns: %w", err)
+	}
+
+	g.token, g.issuedAt = token, now
+
+	return g.token, nil
+}
+
+func (g *APNsGateway) resetToken() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.token = ""
+}
@@ -0,0 +1,137 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package providers
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestAPNsGatewaySendPush(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		status int
+		reason string
+		wantID string
+		want   errorKind
+	}{
+		{name: "sent", status: http.StatusOK, wantID: "apns-1"},
+		{name: "bad device token", status: http.StatusBadRequest, reason: "BadDeviceToken", want: kindTokenInvalid},
+		{name: "wrong topic", status: http.StatusBadRequest, reason: "DeviceTokenNotForTopic", want: kindTokenInvalid},
+		{name: "unregistered", status: http.StatusGone, reason: "Unregistered", want: kindTokenInvalid},
+		{name: "gone without reason", status: http.StatusGone, want: kindTokenInvalid},
+		{name: "expired provider token", status: http.StatusForbidden, reason: "ExpiredProviderToken", want: kindRetryable},
+		{name: "bad topic", status: http.StatusBadRequest, reason: "BadTopic", want: kindRetryable},
+		{name: "payload too large", status: http.StatusRequestEntityTooLarge, reason: "PayloadTooLarge", want: kindPermanent},
+		{name: "bad message", status: http.StatusBadRequest, reason: "BadMessageId", want: kindPermanent},
+		{name: "too many requests", status: http.StatusTooManyRequests, reason: "TooManyRequests", want: kindRetryable},
+		{name: "unavailable", status: http.StatusServiceUnavailable, reason: "ServiceUnavailable", want: kindRetryable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/3/device/device-token" {
+					t.Errorf("path = %s", r.URL.Path)
+				}
+				if r.Header.Get("apns-topic") != "com.example.app" || r.Header.Get("apns-priority") != "5" {
+					t.Errorf("headers = %v", r.Header)
+				}
+				token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "bearer ")
+				if !ok {
+					t.Errorf("Authorization = %q", r.Header.Get("Authorization"))
+				}
+				verifyES256(t, &key.PublicKey, token)
+
+				if tt.status == http.StatusOK {
+					w.Header().Set("apns-id", "apns-1")
+					return
+				}
+				w.WriteHeader(tt.status)
+				if tt.reason != "" {
+					_ = json.NewEncoder(w).Encode(map[string]string{"reason": tt.reason})
+				}
+			}))
+			defer server.Close()
+
+			gateway := &APNsGateway{
+				baseURL: server.URL,
+				topic:   "com.example.app",
+				keyID:   "KEY123",
+				teamID:  "TEAM123",
+				key:     key,
+				client:  server.Client(),
+			}
+
+			id, err := gateway.SendPush(context.Background(), "device-token", &Push{Title: "Hi", Body: "Hello", Priority: models.PushPriorityNormal})
+			if got := classify(err); got != tt.want {
+				t.Fatalf("SendPush error = %v, classified %q, want %q", err, got, tt.want)
+			}
+			if id != tt.wantID {
+				t.Errorf("apns-id = %q, want %q", id, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestAPNsGatewayResetsExpiredToken(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gateway := &APNsGateway{keyID: "KEY123", teamID: "TEAM123", key: key}
+
+	first, err := gateway.providerToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again, _ := gateway.providerToken(); again != first {
+		t.Error("provider token isn't reused")
+	}
+
+	_ = gateway.apnsError(http.StatusForbidden, "ExpiredProviderToken")
+	if gateway.token != "" {
+		t.Error("provider token kept after ExpiredProviderToken")
+	}
+}
+
+// verifyES256 checks that token is a JWT signed by the P-256 key of pub.
+func verifyES256(t *testing.T, pub *ecdsa.PublicKey, token string) {
+	t.Helper()
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("JWT %q has %d parts", token, len(parts))
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil || len(signature) != 64 {
+		t.Fatalf("signature of %d bytes: %v", len(signature), err)
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	r, s := new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])
+	if !ecdsa.Verify(pub, digest[:], r, s) {
+		t.Error("JWT signature doesn't verify")
+	}
+}
@@ -0,0 +1,215 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/config"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/services"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/constants"
+	"go.uber.org/zap"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// NewEmailProvider returns the email provider selected with EMAIL_PROVIDER: smtp, or log, the default,
+// which only logs the notifications.
+func NewEmailProvider(logger *zap.Logger) (services.Provider, error) {
+	switch kind := config.GetString("EMAIL_PROVIDER", "log"); kind {
+	case "smtp":
+		return NewSMTPProvider(logger)
+	case "log":
+		return NewLogProvider(logger), nil
+	default:
+		return nil, fmt.Errorf("unknown email provider %q, expected \"smtp\" or \"log\"", kind)
+	}
+}
+
+// email is the content of an email notification. Besides the title, used as subject, and the message,
+// used as plain-text body, it is read from the notification metadata:
+//   - html: an HTML alternative of the body.
+//   - headers: an object of additional header fields.
+//   - attachments: a list of URLs, or of objects with url and optionally filename and content_type, of the
+//     files to attach.
+type email struct {
+	html        string
+	headers     map[string]string
+	attachments []attachmentRef
+}
+
+type attachmentRef struct {
+	url         string
+	filename    string
+	contentType string
+}
+
+type attachment struct {
+	filename    string
+	contentType string
+	data        []byte
+}
+
+func parseEmail(notification *models.Notification) (*email, error) {
+	e := &email{
+		headers: make(map[string]string),
+	}
+
+	if v, ok := notification.MetaData[string(constants.MetaDataHTML)]; ok {
+		html, ok := v.(string)
+		if !ok {
+			return nil, fmt.Errorf("metadata %s must be a string", constants.MetaDataHTML)
+		}
+		e.html = html
+	}
+
+	if v, ok := notification.MetaData[string(constants.MetaDataHeaders)]; ok {
+		headers, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("metadata %s must be an object", constants.MetaDataHeaders)
+		}
+		for name, value := range headers {
+			s, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("metadata %s: value of %s must be a string", constants.MetaDataHeaders, name)
+			}
+			e.headers[name] = s
+		}
+	}
+
+	if v, ok := notification.MetaData[string(constants.MetaDataAttachments)]; ok {
+		items, ok := v.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("metadata %s must be a list", constants.MetaDataAttachments)
+		}
+		for i, item := range items {
+			ref, err := parseAttachmentRef(item)
+			if err != nil {
+				return nil, fmt.Errorf("metadata %s[%d]: %w", constants.MetaDataAttachments, i, err)
+			}
+			e.attachments = append(e.attachments, ref)
+		}
+	}
+
+	return e, nil
+}
+
+func parseAttachmentRef(item interface{}) (attachmentRef, error) {
+	switch v := item.(type) {
+	case string:
+		return attachmentRef{url: v}, nil
+	case map[string]interface{}:
+		ref := attachmentRef{}
+		ref.url, _ = v["url"].(string)
+		ref.filename, _ = v["filename"].(string)
+		ref.contentType, _ = v["content_type"].(string)
+		if ref.url == "" {
+			return attachmentRef{}, errors.New("url is required")
+		}
+		return ref, nil
+	default:
+		return attachmentRef{}, errors.New("must be a URL or an object")
+	}
+}
+
+// attachmentFetcher downloads attachments from allowedHosts, bounding the total size of the attachments of a
+// message. With no allowed hosts, attachments are refused: the URLs come from the callers of the API, which
+// could otherwise have internal resources mailed to them. The client doesn't follow redirects, which could
+// lead to any host, and refuses addresses that aren't public, see newOutboundClient.
+type attachmentFetcher struct {
+	client       *http.Client
+	maxBytes     int64
+	allowedHosts map[string]bool
+}
+
+func (f *attachmentFetcher) fetchAll(ctx context.Context, refs []attachmentRef) ([]attachment, error) {
+	attachments := make([]attachment, 0, len(refs))
+	remaining := f.maxBytes
+
+	for _, ref := range refs {
+		a, err := f.fetch(ctx, ref, remaining)
+		if err != nil {
+			return nil, err
+		}
+		remaining -= int64(len(a.data))
+		attachments = append(attachments, a)
+	}
+
+	return attachments, nil
+}
+
+func (f *attachmentFetcher) fetch(ctx context.Context, ref attachmentRef, maxBytes int64) (attachment, error) {
+	u, err := url.Parse(ref.url)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return attachment{}, services.Permanent(fmt.Errorf("invalid attachment URL %q", ref.url))
+	}
+	if len(f.allowedHosts) == 0 {
+		return attachment{}, services.Permanent(errors.New("attachments are disabled, SMTP_ATTACHMENT_ALLOWED_HOSTS is not set"))
+	}
+	if !f.allowedHosts[strings.ToLower(u.Hostname())] {
+		return attachment{}, services.Permanent(fmt.Errorf("attachment host %s is not allowed", u.Hostname()))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return attachment{}, services.Permanent(fmt.Errorf("attachment %s: %w", u.Redacted(), err))
+	}
+
+	resp, err := f.client.Do(req)
+	if errors.Is(err, errForbiddenAddress) {
+		return attachment{}, services.Permanent(fmt.Errorf("download attachment %s: %w", u.Redacted(), err))
+	}
+	if err != nil {
+		return attachment{}, services.Retryable(fmt.Errorf("download attachment %s: %w", u.Redacted(), err))
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	switch {
+	case resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests:
+		return attachment{}, services.Retryable(fmt.Errorf("download attachment %s: %s", u.Redacted(), resp.Status))
+	case resp.StatusCode >= 300:
+		return attachment{}, services.Permanent(fmt.Errorf("download attachment %s: %s", u.Redacted(), resp.Status))
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return attachment{}, services.Retryable(fmt.Errorf("download attachment %s: %w", u.Redacted(), err))
+	}
+	if int64(len(data)) > maxBytes {
+		return attachment{}, services.Permanent(fmt.Errorf("attachments exceed %d bytes", f.maxBytes))
+	}
+
+	a := attachment{
+		filename:    ref.filename,
+		contentType: ref.contentType,
+		data:        data,
+	}
+	if a.filename == "" {
+		a.filename = path.Base(u.Path)
+		if a.filename == "/" || a.filename == "." {
+			a.filename = "attachment"
+		}
+	}
+	if a.contentType == "" {
+		a.contentType = resp.Header.Get("Content-Type")
+	}
+	if a.contentType == "" {
+		a.contentType = mime.TypeByExtension(path.Ext(a.filename))
+	}
+	if a.contentType == "" {
+		a.contentType = "application/octet-stream"
+	}
+
+	return a, nil
+}
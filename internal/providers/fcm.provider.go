@@ -0,0 +1,295 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package providers
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/config"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/services"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const fcmScope = "https://www.googleapis.com/auth/firebase.messaging"
+
+// serviceAccount is the part of a Google service account key file used to authenticate.
+type serviceAccount struct {
+	ProjectID    string `json:"project_id"`
+	PrivateKeyID string `json:"private_key_id"`
+	PrivateKey   string `json:"private_key"`
+	ClientEmail  string `json:"client_email"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// FCMGateway sends push notifications through the FCM HTTP v1 API. It authenticates with OAuth 2.0 access
+// tokens obtained with a JWT signed by the service account of PUSH_FCM_CREDENTIALS_FILE. PUSH_FCM_BASE_URL
+// and PUSH_FCM_TOKEN_URL override the API and token endpoints, e.g. to use local stubs.
+type FCMGateway struct {
+	baseURL     string
+	tokenURL    string
+	projectID   string
+	clientEmail string
+	keyID       string
+	key         crypto.Signer
+	client      *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	expiresAt   time.Time
+}
+
+func NewFCMGateway() (*FCMGateway, error) {
+	path := config.GetString("PUSH_FCM_CREDENTIALS_FILE", "")
+	if path == "" {
+		return nil, errors.New("PUSH_FCM_CREDENTIALS_FILE is required")
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read FCM credentials: %w", err)
+	}
+
+	var account serviceAccount
+	if err := json.Unmarshal(data, &account); err != nil {
+		return nil, fmt.Errorf("decode FCM credentials: %w", err)
+	}
+
+	key, err := parsePrivateKey([]byte(account.PrivateKey))
+	if err != nil {
+		return nil, fmt.Errorf("FCM credentials private key: %w", err)
+	}
+
+	g := &FCMGateway{
+		baseURL:     strings.TrimSuffix(config.GetString("PUSH_FCM_BASE_URL", "https://fcm.googleapis.com"), "/"),
+		tokenURL:    config.GetString("PUSH_FCM_TOKEN_URL", account.TokenURI),
+		projectID:   config.GetString("PUSH_FCM_PROJECT_ID", account.ProjectID),
+		clientEmail: account.ClientEmail,
+		keyID:       account.PrivateKeyID,
+		key:         key,
+		client: &http.Client{
+			Timeout: time.Duration(config.GetInt("PUSH_TIMEOUT_MS", 10000)) * time.Millisecond,
+		},
+	}
+	if g.tokenURL == "" {
+		g.tokenURL = "https://oauth2.googleapis.com/token"
+	}
+	if g.projectID == "" || g.clientEmail == "" {
+		return nil, errors.New("FCM credentials have no project_id or client_email")
+	}
+
+	return g, nil
+}
+
+func (g *FCMGateway) Name() string {
+	return "fcm"
+}
+
+// SendPush sends a message to the registration token and returns the message name. The codes UNREGISTERED
+// and SENDER_ID_MISMATCH invalidate the token, INVALID_ARGUMENT is permanent, and the codes of a failure of
+// the service, its quota or authentication are retryable.
+func (g *FCMGateway) SendPush(ctx context.Context, token string, push *Push) (string, error) {
+	accessToken, err := g.token(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(map[string]interface{}{"message": fcmMessage(token, push)})
+	if err != nil {
+		return "", services.Permanent(fmt.Errorf("fcm: encode message: %w", err))
+	}
+
+	target := fmt.Sprintf("%s/v1/projects/%s/messages:send", g.baseURL, url.PathEscape(g.projectID))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("fcm: create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return "", services.Retryable(fmt.Errorf("fcm: %w", err))
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", services.Retryable(fmt.Errorf("fcm: read response: %w", err))
+	}
+
+	if resp.StatusCode >= 300 {
+		if resp.StatusCode == http.StatusUnauthorized {
+			g.resetToken()
+		}
+		return "", fcmError(resp.StatusCode, data)
+	}
+
+	var sent struct {
+		Name string `json:"name"`
+	}
+	_ = json.Unmarshal(data, &sent)
+
+	return sent.Name, nil
+}
+
+func fcmMessage(token string, push *Push) map[string]interface{} {
+	message := map[string]interface{}{
+		"token": token,
+		"notification": map[string]string{
+			"title": push.Title,
+			"body":  push.Body,
+		},
+	}
+	if len(push.Data) > 0 {
+		message["data"] = push.Data
+	}
+
+	android := map[string]interface{}{
+		"priority": strings.ToUpper(string(push.Priority)),
+	}
+	if push.Sound != "" {
+		android["notification"] = map[string]string{"sound": push.Sound}
+	}
+	message["android"] = android
+
+	aps := map[string]interface{}{}
+	if push.Badge != nil {
+		aps["badge"] = *push.Badge
+	}
+	if push.Sound != "" {
+		aps["sound"] = push.Sound
+	}
+	apnsPriority := "10"
+	if push.Priority == models.PushPriorityNormal {
+		apnsPriority = "5"
+	}
+	message["apns"] = map[string]interface{}{
+		"headers": map[string]string{"apns-priority": apnsPriority},
+		"payload": map[string]interface{}{"aps": aps},
+	}
+
+	return message
+}
+
+func fcmError(status int, data []byte) error {
+	var body struct {
+		Error struct {
+			Message string `json:"message"`
+			Status  string `json:"status"`
+			Details []struct {
+				Type      string `json:"@type"`
+				ErrorCode string `json:"errorCode"`
+			} `json:"details"`
+		} `json:"error"`
+	}
+	_ = json.Unmarshal(data, &body)
+
+	code := body.Error.Status
+	for _, detail := range body.Error.Details {
+		if strings.HasSuffix(detail.Type, "google.firebase.fcm.v1.FcmError") && detail.ErrorCode != "" {
+			code = detail.ErrorCode
+		}
+	}
+
+	message := body.Error.Message
+	if message == "" {
+		message = http.StatusText(status)
+	}
+	err := fmt.Errorf("fcm: %d %s: %s", status, code, message)
+
+	switch code {
+	case "UNREGISTERED", "SENDER_ID_MISMATCH":
+		return &TokenInvalidError{Reason: code}
+	case "INVALID_ARGUMENT":
+		return services.Permanent(err)
+	case "QUOTA_EXCEEDED", "UNAVAILABLE", "INTERNAL", "THIRD_PARTY_AUTH_ERROR", "UNAUTHENTICATED", "PERMISSION_DENIED":
+		return services.Retryable(err)
+	}
+	if status >= 500 || status == http.StatusTooManyRequests {
+		return services.Retryable(err)
+	}
+	return services.Permanent(err)
+}
+
+// token returns a cached access token, requesting a new one when it expires within a minute.
+func (g *FCMGateway) token(ctx context.Context) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.accessToken != "" && time.Until(g.expiresAt) > time.Minute {
+		return g.accessToken, nil
+	}
+
+	now := time.Now()
+	assertion, err := signJWT(g.key, g.keyID, map[string]interface{}{
+		"iss":   g.clientEmail,
+		"scope": fcmScope,
+		"aud":   g.tokenURL,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("fcm: %w", err)
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "urn:ietf:params:oauth:grant-type:jwt-bearer")
+	form.Set("assertion", assertion)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("fcm: create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return "", services.Retryable(fmt.Errorf("fcm: request access token: %w", err))
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	var token struct {
+		AccessToken      string `json:"access_token"`
+		ExpiresIn        int    `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&token); err != nil && resp.StatusCode < 300 {
+		return "", services.Retryable(fmt.Errorf("fcm: decode access token: %w", err))
+	}
+	if resp.StatusCode >= 300 || token.AccessToken == "" {
+		// a rejected service account is a configuration problem, the notification is fine
+		return "", services.Retryable(fmt.Errorf("fcm: request access token: %s %s %s", resp.Status, token.Error, token.ErrorDescription))
+	}
+
+	g.accessToken = token.AccessToken
+	g.expiresAt = now.Add(time.Duration(token.ExpiresIn) * time.Second)
+
+	return g.accessToken, nil
+}
+
+func (g *FCMGateway) resetToken() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	g.accessToken = ""
+}
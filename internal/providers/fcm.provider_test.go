@@ -0,0 +1,199 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package providers
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/services"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// errorKind is how a push gateway error is handled by the push provider.
+type errorKind string
+
+const (
+	kindNone         errorKind = ""
+	kindPermanent    errorKind = "permanent"
+	kindRetryable    errorKind = "retryable"
+	kindTokenInvalid errorKind = "token invalid"
+)
+
+func classify(err error) errorKind {
+	var invalid *TokenInvalidError
+	switch {
+	case err == nil:
+		return kindNone
+	case errors.As(err, &invalid):
+		return kindTokenInvalid
+	case services.IsPermanent(err):
+		return kindPermanent
+	default:
+		return kindRetryable
+	}
+}
+
+func TestFCMGatewaySendPush(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fcmErrorBody := func(status, code string) string {
+		return `{"error": {"message": "failed", "status": "` + status + `", "details": [{"@type": "type.googleapis.com/google.firebase.fcm.v1.FcmError", "errorCode": "` + code + `"}]}}`
+	}
+
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		wantName string
+		want     errorKind
+	}{
+		{name: "sent", status: http.StatusOK, body: `{"name": "projects/p1/messages/1"}`, wantName: "projects/p1/messages/1"},
+		{name: "unregistered", status: http.StatusNotFound, body: fcmErrorBody("NOT_FOUND", "UNREGISTERED"), want: kindTokenInvalid},
+		{name: "sender mismatch", status: http.StatusForbidden, body: fcmErrorBody("PERMISSION_DENIED", "SENDER_ID_MISMATCH"), want: kindTokenInvalid},
+		{name: "invalid argument", status: http.StatusBadRequest, body: fcmErrorBody("INVALID_ARGUMENT", "INVALID_ARGUMENT"), want: kindPermanent},
+		{name: "quota", status: http.StatusTooManyRequests, body: fcmErrorBody("RESOURCE_EXHAUSTED", "QUOTA_EXCEEDED"), want: kindRetryable},
+		{name: "APNs auth", status: http.StatusUnauthorized, body: fcmErrorBody("UNAUTHENTICATED", "THIRD_PARTY_AUTH_ERROR"), want: kindRetryable},
+		{name: "permission denied", status: http.StatusForbidden, body: `{"error": {"message": "denied", "status": "PERMISSION_DENIED"}}`, want: kindRetryable},
+		{name: "unavailable", status: http.StatusServiceUnavailable, body: `unavailable`, want: kindRetryable},
+		{name: "other 4xx", status: http.StatusNotFound, body: `{"error": {"message": "no such project", "status": "NOT_FOUND"}}`, want: kindPermanent},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+				if err := r.ParseForm(); err != nil {
+					t.Errorf("parse form: %v", err)
+				}
+				verifyRS256(t, &key.PublicKey, r.PostForm.Get("assertion"))
+
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"access_token": "access-1", "expires_in": 3600}`))
+			})
+			mux.HandleFunc("/v1/projects/p1/messages:send", func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Authorization"); got != "Bearer access-1" {
+					t.Errorf("Authorization = %q", got)
+				}
+
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			})
+
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			gateway := &FCMGateway{
+				baseURL:     server.URL,
+				tokenURL:    server.URL + "/token",
+				projectID:   "p1",
+				clientEmail: "sender@p1.iam.gserviceaccount.com",
+				keyID:       "key-1",
+				key:         key,
+				client:      server.Client(),
+			}
+
+			name, err := gateway.SendPush(context.Background(), "device-token", &Push{Title: "Hi", Body: "Hello"})
+			if got := classify(err); got != tt.want {
+				t.Fatalf("SendPush error = %v, classified %q, want %q", err, got, tt.want)
+			}
+			if name != tt.wantName {
+				t.Errorf("name = %q, want %q", name, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestFCMGatewayAccessToken(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tokenRequests, sendStatus := 0, http.StatusUnauthorized
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		tokenRequests++
+		_, _ = w.Write([]byte(`{"access_token": "access-1", "expires_in": 3600}`))
+	})
+	mux.HandleFunc("/v1/projects/p1/messages:send", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(sendStatus)
+		_, _ = w.Write([]byte(`{"error": {"message": "expired", "status": "UNAUTHENTICATED"}}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	gateway := &FCMGateway{baseURL: server.URL, tokenURL: server.URL + "/token", projectID: "p1", clientEmail: "sender", key: key, client: server.Client()}
+
+	// a 401 drops the cached access token, the next message gets a new one
+	for i := 0; i < 2; i++ {
+		if _, err := gateway.SendPush(context.Background(), "device-token", &Push{}); classify(err) != kindRetryable {
+			t.Fatalf("SendPush error = %v, want a retryable error", err)
+		}
+	}
+	if tokenRequests != 2 {
+		t.Errorf("token requests = %d, want 2", tokenRequests)
+	}
+
+	// and a cached token is reused
+	sendStatus = http.StatusInternalServerError
+	for i := 0; i < 2; i++ {
+		_, _ = gateway.SendPush(context.Background(), "device-token", &Push{})
+	}
+	if tokenRequests != 3 {
+		t.Errorf("token requests = %d, want 3", tokenRequests)
+	}
+}
+
+func TestFCMGatewayRejectedServiceAccount(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error": "invalid_grant", "error_description": "Invalid JWT Signature."}`))
+	}))
+	defer server.Close()
+
+	gateway := &FCMGateway{baseURL: server.URL, tokenURL: server.URL, projectID: "p1", clientEmail: "sender", key: key, client: server.Client()}
+
+	// the account's configuration is at fault, not the notification
+	if _, err := gateway.SendPush(context.Background(), "device-token", &Push{}); classify(err) != kindRetryable {
+		t.Errorf("SendPush error = %v, want a retryable error", err)
+	}
+}
+
+// verifyRS256 checks that token is a JWT signed by the RSA key of pub.
+func verifyRS256(t *testing.T, pub *rsa.PublicKey, token string) {
+	t.Helper()
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		t.Fatalf("JWT %q has %d parts", token, len(parts))
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		t.Fatalf("decode signature: %v", err)
+	}
+
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], signature); err != nil {
+		t.Errorf("JWT signature: %v", err)
+	}
+}
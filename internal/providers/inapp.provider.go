@@ -0,0 +1,52 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package providers
+
+import (
+	"context"
+	"errors"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/services"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// InboxStore stores the notifications of the in-app inboxes, implemented by services.RabbitMQService.
+type InboxStore interface {
+	DeliverToInbox(ctx context.Context, notification *models.Notification) error
+}
+
+// InAppProvider stores notifications in the inbox of their recipient, a user ID, from which the app
+// retrieves them through the inbox API.
+type InAppProvider struct {
+	inbox  InboxStore
+	logger *zap.Logger
+}
+
+func NewInAppProvider(inbox InboxStore, logger *zap.Logger) *InAppProvider {
+	return &InAppProvider{
+		inbox:  inbox,
+		logger: logger,
+	}
+}
+
+func (p *InAppProvider) Name() string {
+	return "inbox"
+}
+
+func (p *InAppProvider) Send(ctx context.Context, notification *models.Notification) error {
+	err := p.inbox.DeliverToInbox(ctx, notification)
+	if errors.Is(err, services.ErrInvalidRecipient) {
+		return services.Permanent(err)
+	}
+	if err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx, p.logger).Debug("Notification stored in inbox", zap.String("id", notification.ID))
+
+	return nil
+}
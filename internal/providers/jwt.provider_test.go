@@ -0,0 +1,169 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package providers
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestSignJWT(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		key    crypto.Signer
+		alg    string
+		verify func(digest, signature []byte) bool
+	}{
+		{
+			name: "RS256",
+			key:  rsaKey,
+			alg:  "RS256",
+			verify: func(digest, signature []byte) bool {
+				return rsa.VerifyPKCS1v15(&rsaKey.PublicKey, crypto.SHA256, digest, signature) == nil
+			},
+		},
+		{
+			name: "ES256",
+			key:  ecKey,
+			alg:  "ES256",
+			verify: func(digest, signature []byte) bool {
+				// r and s, each left-padded to 32 bytes
+				if len(signature) != 64 {
+					return false
+				}
+				r := new(big.Int).SetBytes(signature[:32])
+				s := new(big.Int).SetBytes(signature[32:])
+				return ecdsa.Verify(&ecKey.PublicKey, digest, r, s)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// signatures of different lengths come out of ECDSA, sign a few times to cover the padding
+			for i := 0; i < 20; i++ {
+				token, err := signJWT(tt.key, "key-1", map[string]interface{}{"iss": "team", "iat": 1700000000})
+				if err != nil {
+					t.Fatalf("signJWT: %v", err)
+				}
+
+				parts := strings.Split(token, ".")
+				if len(parts) != 3 {
+					t.Fatalf("token has %d parts, want 3", len(parts))
+				}
+
+				var header map[string]string
+				decodeJWTPart(t, parts[0], &header)
+				if header["alg"] != tt.alg || header["kid"] != "key-1" || header["typ"] != "JWT" {
+					t.Errorf("header = %v", header)
+				}
+
+				var claims map[string]interface{}
+				decodeJWTPart(t, parts[1], &claims)
+				if claims["iss"] != "team" || claims["iat"] != float64(1700000000) {
+					t.Errorf("claims = %v", claims)
+				}
+
+				signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+				if err != nil {
+					t.Fatalf("decode signature: %v", err)
+				}
+
+				digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+				if !tt.verify(digest[:], signature) {
+					t.Fatalf("signature of %s doesn't verify", token)
+				}
+			}
+		})
+	}
+}
+
+func TestSignJWTRejectsOtherCurves(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := signJWT(key, "", map[string]interface{}{}); err == nil {
+		t.Error("signJWT accepted a P-384 key for ES256")
+	}
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pkcs8RSA, err := x509.MarshalPKCS8PrivateKey(rsaKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkcs8EC, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr bool
+	}{
+		{name: "PKCS #8 RSA", data: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8RSA})},
+		{name: "PKCS #8 EC", data: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8EC})},
+		{name: "PKCS #1 RSA", data: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})},
+		{name: "not PEM", data: []byte("not a key"), wantErr: true},
+		{name: "not a key", data: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("garbage")}), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := parsePrivateKey(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePrivateKey error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && key == nil {
+				t.Fatal("parsePrivateKey returned no key")
+			}
+		})
+	}
+}
+
+func decodeJWTPart(t *testing.T, part string, v interface{}) {
+	t.Helper()
+
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		t.Fatalf("decode %q: %v", part, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatalf("unmarshal %s: %v", data, err)
+	}
+}
@@ -0,0 +1,37 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package providers
+
+import (
+	"context"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// LogProvider only logs the notifications it is given, for channels without a real provider configured.
+type LogProvider struct {
+	logger *zap.Logger
+}
+
+func NewLogProvider(logger *zap.Logger) *LogProvider {
+	return &LogProvider{
+		logger: logger,
+	}
+}
+
+func (p *LogProvider) Name() string {
+	return "log"
+}
+
+func (p *LogProvider) Send(ctx context.Context, notification *models.Notification) error {
+	logger.FromContext(ctx, p.logger).Info("Sending notification",
+		zap.String("type", string(notification.Type)),
+		zap.String("recipient", notification.Recipient),
+		zap.String("message", notification.Message),
+	)
+	return nil
+}
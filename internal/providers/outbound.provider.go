@@ -0,0 +1,82 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// errForbiddenAddress is returned when a URL taken from a notification resolves to an address that isn't
+// public.
+var errForbiddenAddress = errors.New("address is not public")
+
+// nonPublicPrefixes are the ranges that aren't public and that net/netip doesn't classify as such: "this
+// network" and the carrier-grade NAT range.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+}
+
+// newOutboundClient returns the HTTP client for URLs that come from notifications, which the worker must not
+// be made to reach internal services through. It doesn't follow redirects and, unless allowPrivate is set,
+// refuses to connect to loopback, private, link-local and other addresses that aren't public. The address is
+// checked when connecting, after DNS resolution, so a public name resolving to an internal address is
+// refused too. Proxies from the environment are not used, they would connect on the client's behalf.
+func newOutboundClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+	}
+	if !allowPrivate {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			return checkPublicAddress(address)
+		}
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, network, address string) (net.Conn, error) {
+				return dialer.DialContext(ctx, network, address)
+			},
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// checkPublicAddress checks the ip:port address a connection is about to be made to.
+func checkPublicAddress(address string) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errForbiddenAddress, address)
+	}
+
+	ip := addrPort.Addr().Unmap()
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() {
+		return fmt.Errorf("%w: %s", errForbiddenAddress, ip)
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(ip) {
+			return fmt.Errorf("%w: %s", errForbiddenAddress, ip)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,246 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/config"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/services"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/constants"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/logger"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/mask"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PushGateway sends a push notification to a device through a push service. Errors follow the convention
+// of services.Provider, and a *TokenInvalidError is returned when the service reports the device token as
+// no longer valid.
+type PushGateway interface {
+	// Name identifies the push service in logs, spans and events, e.g. "fcm".
+	Name() string
+	// SendPush sends push to the device with token and returns the service's ID of the message.
+	SendPush(ctx context.Context, token string, push *Push) (string, error)
+}
+
+// EventPublisher publishes the events of the providers, implemented by services.RabbitMQService.
+type EventPublisher interface {
+	PublishEvent(ctx context.Context, exchange, routingKey string, event interface{}, opts ...services.PublishOption) error
+}
+
+// TokenInvalidError is returned by a PushGateway when the device token is no longer valid, e.g. because the
+// app was uninstalled. Sending to it again will fail the same way.
+type TokenInvalidError struct {
+	Reason string
+}
+
+func (e *TokenInvalidError) Error() string {
+	return fmt.Sprintf("device token invalid: %s", e.Reason)
+}
+
+// Push is the content of a push notification. Title and Body come from the notification; the other fields
+// from its metadata, whose remaining keys make the data payload.
+type Push struct {
+	Title    string
+	Body     string
+	Data     map[string]string
+	Badge    *int
+	Sound    string
+	Priority models.PushPriority
+}
+
+// NewPushProvider returns the push provider selected with PUSH_PROVIDERS, a comma separated list of fcm and
+// apns, or log, the default, which only logs the notifications.
+func NewPushProvider(events EventPublisher, logger *zap.Logger) (services.Provider, error) {
+	kinds := strings.Split(config.GetString("PUSH_PROVIDERS", "log"), ",")
+	for i := range kinds {
+		kinds[i] = strings.TrimSpace(kinds[i])
+	}
+
+	// the log provider replaces delivery altogether, listing it with a gateway would drop that gateway
+	if slices.Contains(kinds, "log") {
+		if len(kinds) > 1 {
+			return nil, fmt.Errorf("push provider \"log\" can't be combined with other providers, got %q", strings.Join(kinds, ","))
+		}
+		return NewLogProvider(logger), nil
+	}
+
+	gateways := make(map[models.PushPlatform]PushGateway)
+
+	for _, kind := range kinds {
+		switch kind {
+		case "fcm":
+			gateway, err := NewFCMGateway()
+			if err != nil {
+				return nil, err
+			}
+			gateways[models.PushPlatformAndroid] = gateway
+			// FCM delivers to iOS devices through APNs itself, unless APNs is used directly
+			if _, ok := gateways[models.PushPlatformIOS]; !ok {
+				gateways[models.PushPlatformIOS] = gateway
+			}
+		case "apns":
+			gateway, err := NewAPNsGateway()
+			if err != nil {
+				return nil, err
+			}
+			gateways[models.PushPlatformIOS] = gateway
+		default:
+			return nil, fmt.Errorf("unknown push provider %q, expected \"fcm\", \"apns\" or \"log\"", kind)
+		}
+	}
+
+	return NewGatewayPushProvider(gateways, events, logger), nil
+}
+
+// GatewayPushProvider sends push notifications through the PushGateway of the device's platform, given by
+// the platform metadata or PUSH_DEFAULT_PLATFORM. The recipient is the device token. When a gateway
+// reports the token as invalid, a models.TokenInvalidatedEvent is published and the notification fails
+// permanently.
+type GatewayPushProvider struct {
+	gateways        map[models.PushPlatform]PushGateway
+	defaultPlatform models.PushPlatform
+	events          EventPublisher
+	logger          *zap.Logger
+}
+
+func NewGatewayPushProvider(gateways map[models.PushPlatform]PushGateway, events EventPublisher, logger *zap.Logger) *GatewayPushProvider {
+	return &GatewayPushProvider{
+		gateways:        gateways,
+		defaultPlatform: models.PushPlatform(config.GetString("PUSH_DEFAULT_PLATFORM", string(models.PushPlatformAndroid))),
+		events:          events,
+		logger:          logger,
+	}
+}
+
+func (p *GatewayPushProvider) Name() string {
+	names := make([]string, 0, len(p.gateways))
+	seen := make(map[string]bool)
+	for _, gateway := range p.gateways {
+		if !seen[gateway.Name()] {
+			seen[gateway.Name()] = true
+			names = append(names, gateway.Name())
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
+
+func (p *GatewayPushProvider) Send(ctx context.Context, notification *models.Notification) error {
+	if notification.Recipient == "" {
+		return services.Permanent(errors.New("missing device token"))
+	}
+
+	platform, push, err := parsePush(notification, p.defaultPlatform)
+	if err != nil {
+		return services.Permanent(err)
+	}
+
+	gateway, ok := p.gateways[platform]
+	if !ok {
+		return services.Permanent(fmt.Errorf("no push gateway for platform %q", platform))
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(
+		attribute.String("push.platform", string(platform)),
+		attribute.String("push.gateway", gateway.Name()),
+	)
+
+	log := logger.FromContext(ctx, p.logger)
+
+	id, err := gateway.SendPush(ctx, notification.Recipient, push)
+
+	var invalid *TokenInvalidError
+	if errors.As(err, &invalid) {
+		event := &models.TokenInvalidatedEvent{
+			Token:          notification.Recipient,
+			Platform:       platform,
+			Provider:       gateway.Name(),
+			Reason:         invalid.Reason,
+			NotificationID: notification.ID,
+			InvalidatedAt:  time.Now().UTC(),
+		}
+		if pubErr := p.events.PublishEvent(ctx, string(constants.ExchangePushEvent), string(constants.RoutingKeyPushTokenInvalidated), event); pubErr != nil {
+			// retried, the gateway will report the token again
+			return services.Retryable(fmt.Errorf("%w, and publishing the token invalidated event failed: %w", err, pubErr))
+		}
+
+		log.Info("Device token invalidated", zap.String("id", notification.ID), zap.String("gateway", gateway.Name()), zap.String("token", mask.String(notification.Recipient)), zap.String("reason", invalid.Reason))
+
+		return services.Permanent(err)
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Debug("Push sent", zap.String("id", notification.ID), zap.String("gateway", gateway.Name()), zap.String("gateway_id", id))
+
+	return nil
+}
+
+// parsePush reads the push options of the metadata: platform, badge, sound and priority, high by default.
+// The other keys make the data payload, with values that are not strings encoded as JSON.
+func parsePush(notification *models.Notification, defaultPlatform models.PushPlatform) (models.PushPlatform, *Push, error) {
+	platform := defaultPlatform
+	push := &Push{
+		Title:    notification.Title,
+		Body:     notification.Message,
+		Data:     make(map[string]string),
+		Priority: models.PushPriorityHigh,
+	}
+
+	for key, value := range notification.MetaData {
+		switch constants.MetaDataKey(key) {
+		case constants.MetaDataPlatform:
+			s, _ := value.(string)
+			platform = models.PushPlatform(strings.ToLower(s))
+			if platform != models.PushPlatformAndroid && platform != models.PushPlatformIOS {
+				return "", nil, fmt.Errorf("metadata %s must be %q or %q", key, models.PushPlatformAndroid, models.PushPlatformIOS)
+			}
+		case constants.MetaDataBadge:
+			n, ok := value.(float64)
+			if !ok || n < 0 || n != math.Trunc(n) {
+				return "", nil, fmt.Errorf("metadata %s must be a non-negative integer", key)
+			}
+			badge := int(n)
+			push.Badge = &badge
+		case constants.MetaDataSound:
+			s, ok := value.(string)
+			if !ok {
+				return "", nil, fmt.Errorf("metadata %s must be a string", key)
+			}
+			push.Sound = s
+		case constants.MetaDataPriority:
+			s, _ := value.(string)
+			push.Priority = models.PushPriority(strings.ToLower(s))
+			if push.Priority != models.PushPriorityHigh && push.Priority != models.PushPriorityNormal {
+				return "", nil, fmt.Errorf("metadata %s must be %q or %q", key, models.PushPriorityHigh, models.PushPriorityNormal)
+			}
+		default:
+			if s, ok := value.(string); ok {
+				push.Data[key] = s
+				continue
+			}
+			data, err := json.Marshal(value)
+			if err != nil {
+				return "", nil, fmt.Errorf("metadata %s: %w", key, err)
+			}
+			push.Data[key] = string(data)
+		}
+	}
+
+	return platform, push, nil
+}
@@ -0,0 +1,72 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package providers
+
+import (
+	"fmt"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/services"
+	"go.uber.org/zap"
+	"sort"
+)
+
+// Dependencies are what the providers are built with.
+type Dependencies struct {
+	Events EventPublisher
+	Inbox  InboxStore
+	Logger *zap.Logger
+}
+
+// Factory builds the provider of a channel.
+type Factory func(deps Dependencies) (services.Provider, error)
+
+// factories are the channels notifications can be sent on. The API accepts the notification types listed
+// here and the worker registers their providers, so adding a channel only takes adding its factory.
+var factories = map[models.NotificationType]Factory{
+	models.NotificationTypeEmail: func(deps Dependencies) (services.Provider, error) {
+		return NewEmailProvider(deps.Logger)
+	},
+	models.NotificationTypeSMS: func(deps Dependencies) (services.Provider, error) {
+		return NewSMSProvider(deps.Logger)
+	},
+	models.NotificationTypePush: func(deps Dependencies) (services.Provider, error) {
+		return NewPushProvider(deps.Events, deps.Logger)
+	},
+	models.NotificationTypeWebhook: func(deps Dependencies) (services.Provider, error) {
+		return NewWebhookProvider(deps.Logger), nil
+	},
+	models.NotificationTypeSlack: func(deps Dependencies) (services.Provider, error) {
+		return NewSlackProvider(deps.Logger), nil
+	},
+	models.NotificationTypeInApp: func(deps Dependencies) (services.Provider, error) {
+		return NewInAppProvider(deps.Inbox, deps.Logger), nil
+	},
+}
+
+// Channels returns the notification types that have a provider, sorted.
+func Channels() []models.NotificationType {
+	channels := make([]models.NotificationType, 0, len(factories))
+	for channel := range factories {
+		channels = append(channels, channel)
+	}
+	sort.Slice(channels, func(i, j int) bool {
+		return channels[i] < channels[j]
+	})
+	return channels
+}
+
+// Build builds the provider of every channel.
+func Build(deps Dependencies) (map[models.NotificationType]services.Provider, error) {
+	providers := make(map[models.NotificationType]services.Provider, len(factories))
+	for _, channel := range Channels() {
+		provider, err := factories[channel](deps)
+		if err != nil {
+			return nil, fmt.Errorf("%s provider: %w", channel, err)
+		}
+		providers[channel] = provider
+	}
+	return providers, nil
+}
@@ -0,0 +1,235 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/config"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/services"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/constants"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/logger"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// slackRetryableErrors are the chat.postMessage errors that come from the app's token or from Slack itself,
+// rather than from the message. Other errors are permanent.
+var slackRetryableErrors = map[string]bool{
+	"not_authed":          true,
+	"invalid_auth":        true,
+	"account_inactive":    true,
+	"token_revoked":       true,
+	"token_expired":       true,
+	"missing_scope":       true,
+	"ratelimited":         true,
+	"internal_error":      true,
+	"fatal_error":         true,
+	"service_unavailable": true,
+	"request_timeout":     true,
+}
+
+// SlackProvider posts notifications to Slack. A recipient that is an https URL is an incoming webhook, on
+// one of SLACK_WEBHOOK_HOSTS; any other recipient is the channel, by ID or name, the message is posted to
+// with chat.postMessage as the app of SLACK_BOT_TOKEN. The text is the title in bold followed by the message,
+// both in Slack's mrkdwn, and the blocks metadata, a list of Block Kit blocks, is passed through as is.
+// SLACK_BASE_URL overrides the Web API endpoint, e.g. to use a local stub.
+type SlackProvider struct {
+	baseURL      string
+	token        string
+	webhookHosts map[string]bool
+	client       *http.Client
+	logger       *zap.Logger
+}
+
+func NewSlackProvider(logger *zap.Logger) *SlackProvider {
+	return &SlackProvider{
+		baseURL:      strings.TrimSuffix(config.GetString("SLACK_BASE_URL", "https://slack.com/api"), "/"),
+		token:        config.GetString("SLACK_BOT_TOKEN", ""),
+		webhookHosts: parseHosts(config.GetString("SLACK_WEBHOOK_HOSTS", "hooks.slack.com")),
+		client: &http.Client{
+			Timeout: time.Duration(config.GetInt("SLACK_TIMEOUT_MS", 10000)) * time.Millisecond,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		logger: logger,
+	}
+}
+
+func (p *SlackProvider) Name() string {
+	return "slack"
+}
+
+func (p *SlackProvider) Send(ctx context.Context, notification *models.Notification) error {
+	if notification.Recipient == "" {
+		return services.Permanent(errors.New("missing Slack channel or webhook URL"))
+	}
+
+	payload, err := slackPayload(notification)
+	if err != nil {
+		return services.Permanent(err)
+	}
+
+	webhook := strings.HasPrefix(notification.Recipient, "https://")
+
+	var target *url.URL
+	if webhook {
+		if target, err = checkURL(notification.Recipient, p.webhookHosts); err != nil {
+			return services.Permanent(err)
+		}
+		trace.SpanFromContext(ctx).SetAttributes(attribute.String("slack.mode", "webhook"))
+	} else {
+		payload["channel"] = notification.Recipient
+		trace.SpanFromContext(ctx).SetAttributes(attribute.String("slack.mode", "api"))
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return services.Permanent(fmt.Errorf("encode Slack payload: %w", err))
+	}
+
+	log := logger.FromContext(ctx, p.logger)
+
+	if webhook {
+		if err := p.postWebhook(ctx, target, body); err != nil {
+			return err
+		}
+		log.Debug("Slack webhook delivered", zap.String("id", notification.ID))
+		return nil
+	}
+
+	ts, err := p.postMessage(ctx, body)
+	if err != nil {
+		return err
+	}
+
+	log.Debug("Slack message posted", zap.String("id", notification.ID), zap.String("channel", notification.Recipient), zap.String("ts", ts))
+
+	return nil
+}
+
+func slackPayload(notification *models.Notification) (map[string]interface{}, error) {
+	text := notification.Message
+	if notification.Title != "" {
+		text = fmt.Sprintf("*%s*\n%s", notification.Title, notification.Message)
+	}
+	if strings.TrimSpace(text) == "" {
+		return nil, errors.New("empty message")
+	}
+
+	payload := map[string]interface{}{
+		"text": text,
+	}
+
+	if blocks, ok := notification.MetaData[string(constants.MetaDataBlocks)]; ok {
+		if _, ok := blocks.([]interface{}); !ok {
+			return nil, fmt.Errorf("metadata %s must be a list of blocks", constants.MetaDataBlocks)
+		}
+		payload["blocks"] = blocks
+	}
+
+	return payload, nil
+}
+
+// postWebhook posts body to an incoming webhook, which answers errors with a status and a plain-text
+// code, e.g. 404 channel_not_found. As the webhook is the recipient, only 429 and 5xx are retryable.
+func (p *SlackProvider) postWebhook(ctx context.Context, target *url.URL, body []byte) error {
+	resp, data, err := p.post(ctx, target.String(), "", body)
+	if err != nil {
+		// the URL of a webhook is its secret, errors name the host only
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return services.Retryable(fmt.Errorf("slack webhook %s: %w", target.Host, err))
+	}
+
+	err = fmt.Errorf("slack webhook: %s %s", resp.Status, strings.TrimSpace(string(data)))
+	switch {
+	case resp.StatusCode >= 500, resp.StatusCode == http.StatusTooManyRequests:
+		return services.Retryable(err)
+	case resp.StatusCode >= 300:
+		return services.Permanent(err)
+	}
+
+	return nil
+}
+
+// postMessage posts body with chat.postMessage and returns the timestamp identifying the message.
+func (p *SlackProvider) postMessage(ctx context.Context, body []byte) (string, error) {
+	if p.token == "" {
+		return "", services.Retryable(errors.New("SLACK_BOT_TOKEN is not set"))
+	}
+
+	resp, data, err := p.post(ctx, p.baseURL+"/chat.postMessage", p.token, body)
+	if err != nil {
+		return "", services.Retryable(fmt.Errorf("slack: %w", err))
+	}
+
+	if resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests {
+		return "", services.Retryable(fmt.Errorf("slack: %s", resp.Status))
+	}
+
+	var reply struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+		TS    string `json:"ts"`
+	}
+	if err := json.Unmarshal(data, &reply); err != nil {
+		if resp.StatusCode >= 300 {
+			return "", services.Permanent(fmt.Errorf("slack: %s", resp.Status))
+		}
+		// the message may have been posted, retrying would post it again
+		return "", nil
+	}
+
+	if !reply.OK {
+		err := fmt.Errorf("slack: %s", reply.Error)
+		if slackRetryableErrors[reply.Error] {
+			return "", services.Retryable(err)
+		}
+		return "", services.Permanent(err)
+	}
+
+	return reply.TS, nil
+}
+
+func (p *SlackProvider) post(ctx context.Context, target, token string, body []byte) (*http.Response, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp, data, nil
+}
@@ -0,0 +1,228 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package providers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/config"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/services"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/logger"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+)
+
+// SMSGateway sends a text message through the REST API of an SMS vendor. Errors follow the convention of
+// services.Provider: wrapped with services.Permanent when the message can never be sent as it is.
+type SMSGateway interface {
+	// Name identifies the vendor in logs and spans, e.g. "twilio".
+	Name() string
+	// SendSMS sends body to the E.164 number to and returns the vendor's ID of the message.
+	SendSMS(ctx context.Context, to, body string) (string, error)
+}
+
+// NewSMSProvider returns the SMS provider selected with SMS_PROVIDER: twilio, for a Twilio-compatible API,
+// or log, the default, which only logs the notifications.
+func NewSMSProvider(logger *zap.Logger) (services.Provider, error) {
+	switch kind := config.GetString("SMS_PROVIDER", "log"); kind {
+	case "twilio":
+		gateway, err := NewTwilioGateway()
+		if err != nil {
+			return nil, err
+		}
+		return NewGatewaySMSProvider(gateway, logger), nil
+	case "log":
+		return NewLogProvider(logger), nil
+	default:
+		return nil, fmt.Errorf("unknown SMS provider %q, expected \"twilio\" or \"log\"", kind)
+	}
+}
+
+type SMSEncoding string
+
+const (
+	SMSEncodingGSM7 SMSEncoding = "GSM-7"
+	SMSEncodingUCS2 SMSEncoding = "UCS-2"
+)
+
+var (
+	e164      = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+	phoneJunk = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "")
+
+	// the GSM 03.38 default alphabet and its extension table, whose characters take an escape septet too
+	gsm7Basic     = []rune("@£$¥èéùìòÇ\nØø\rÅåΔ_ΦΓΛΩΠΨΣΘΞÆæßÉ !\"#¤%&'()*+,-./0123456789:;<=>?¡ABCDEFGHIJKLMNOPQRSTUVWXYZÄÖÑÜ§¿abcdefghijklmnopqrstuvwxyzäöñüà")
+	gsm7Extension = []rune("\f^{}\\[~]|€")
+	gsm7Septets   = make(map[rune]int)
+)
+
+func init() {
+	for _, r := range gsm7Basic {
+		gsm7Septets[r] = 1
+	}
+	for _, r := range gsm7Extension {
+		gsm7Septets[r] = 2
+	}
+}
+
+// GatewaySMSProvider sends SMS notifications through an SMSGateway. Recipients are normalized to E.164,
+// national numbers being completed with SMS_DEFAULT_COUNTRY_CODE, and messages are split into GSM-7 or UCS-2
+// segments, of which there may be at most SMS_MAX_SEGMENTS. With SMS_CONCATENATE, the default, the whole
+// message is handed to the gateway, which sends the segments as one concatenated SMS; otherwise each segment
+// is sent as an SMS of its own, for vendors that don't concatenate. A failure then leaves the segments
+// sent before it delivered, and they are sent again when the notification is retried.
+type GatewaySMSProvider struct {
+	gateway            SMSGateway
+	defaultCountryCode string
+	maxSegments        int
+	concatenate        bool
+	logger             *zap.Logger
+}
+
+func NewGatewaySMSProvider(gateway SMSGateway, logger *zap.Logger) *GatewaySMSProvider {
+	return &GatewaySMSProvider{
+		gateway:            gateway,
+		defaultCountryCode: strings.TrimPrefix(config.GetString("SMS_DEFAULT_COUNTRY_CODE", ""), "+"),
+		maxSegments:        config.GetInt("SMS_MAX_SEGMENTS", 10),
+		concatenate:        config.GetBool("SMS_CONCATENATE", true),
+		logger:             logger,
+	}
+}
+
+func (p *GatewaySMSProvider) Name() string {
+	return p.gateway.Name()
+}
+
+func (p *GatewaySMSProvider) Send(ctx context.Context, notification *models.Notification) error {
+	to, err := NormalizeE164(notification.Recipient, p.defaultCountryCode)
+	if err != nil {
+		return services.Permanent(err)
+	}
+
+	if notification.Message == "" {
+		return services.Permanent(errors.New("empty message"))
+	}
+
+	segments, encoding := SplitSMS(notification.Message)
+	if len(segments) > p.maxSegments {
+		return services.Permanent(fmt.Errorf("message takes %d %s segments, at most %d are allowed", len(segments), encoding, p.maxSegments))
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(
+		attribute.String("sms.encoding", string(encoding)),
+		attribute.Int("sms.segments", len(segments)),
+	)
+
+	bodies := segments
+	if p.concatenate {
+		bodies = []string{notification.Message}
+	}
+
+	ids := make([]string, 0, len(bodies))
+	for i, body := range bodies {
+		id, err := p.gateway.SendSMS(ctx, to, body)
+		if err != nil && len(bodies) > 1 {
+			return fmt.Errorf("send part %d of %d: %w", i+1, len(bodies), err)
+		}
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+
+	logger.FromContext(ctx, p.logger).Debug("SMS sent",
+		zap.String("id", notification.ID),
+		zap.String("gateway", p.gateway.Name()),
+		zap.Strings("gateway_ids", ids),
+		zap.String("encoding", string(encoding)),
+		zap.Int("segments", len(segments)),
+	)
+
+	return nil
+}
+
+// NormalizeE164 returns number in E.164 format, e.g. +84912345678. Spaces, dashes, dots and parentheses
+// are dropped and a 00 international prefix is replaced with +. A national number, without either prefix,
+// gets defaultCountryCode in place of its leading trunk 0, and is an error when defaultCountryCode is empty.
+func NormalizeE164(number, defaultCountryCode string) (string, error) {
+	n := phoneJunk.Replace(strings.TrimSpace(number))
+
+	switch {
+	case strings.HasPrefix(n, "+"):
+	case strings.HasPrefix(n, "00"):
+		n = "+" + n[2:]
+	case defaultCountryCode != "":
+		n = "+" + defaultCountryCode + strings.TrimPrefix(n, "0")
+	default:
+		return "", errors.New("phone number has no country code")
+	}
+
+	if !e164.MatchString(n) {
+		return "", errors.New("invalid phone number")
+	}
+
+	return n, nil
+}
+
+// SplitSMS splits message into the segments it is sent as and returns the encoding they use: GSM-7 when
+// every character is in the GSM 03.38 alphabet, UCS-2 otherwise. A message that fits in a single SMS, 160
+// GSM-7 septets or 70 UCS-2 code units, is one segment; a longer one is split into segments of 153 septets
+// or 67 code units, leaving room for the concatenation header, without splitting an escaped GSM-7
+// character or a UTF-16 surrogate pair.
+func SplitSMS(message string) ([]string, SMSEncoding) {
+	runes := []rune(message)
+
+	encoding := SMSEncodingGSM7
+	for _, r := range runes {
+		if gsm7Septets[r] == 0 {
+			encoding = SMSEncodingUCS2
+			break
+		}
+	}
+
+	// the length of a rune in the unit of the encoding
+	size := func(r rune) int {
+		if encoding == SMSEncodingGSM7 {
+			return gsm7Septets[r]
+		}
+		return len(utf16.Encode([]rune{r}))
+	}
+
+	single, multi := 160, 153
+	if encoding == SMSEncodingUCS2 {
+		single, multi = 70, 67
+	}
+
+	total := 0
+	for _, r := range runes {
+		total += size(r)
+	}
+	if total <= single {
+		return []string{message}, encoding
+	}
+
+	var (
+		segments []string
+		current  []rune
+		length   int
+	)
+	for _, r := range runes {
+		if length+size(r) > multi {
+			segments = append(segments, string(current))
+			current, length = nil, 0
+		}
+		current = append(current, r)
+		length += size(r)
+	}
+	segments = append(segments, string(current))
+
+	return segments, encoding
+}
@@ -0,0 +1,106 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package providers
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"unicode/utf16"
+)
+
+func TestNormalizeE164(t *testing.T) {
+	tests := []struct {
+		name               string
+		number             string
+		defaultCountryCode string
+		want               string
+		wantErr            bool
+	}{
+		{name: "E.164", number: "+84912345678", want: "+84912345678"},
+		{name: "formatted", number: " +1 (415) 555-0100 ", want: "+14155550100"},
+		{name: "dots", number: "+44.20.7946.0958", want: "+442079460958"},
+		{name: "00 prefix", number: "0084912345678", want: "+84912345678"},
+		{name: "national with trunk 0", number: "0912 345 678", defaultCountryCode: "84", want: "+84912345678"},
+		{name: "national without trunk 0", number: "4155550100", defaultCountryCode: "1", want: "+14155550100"},
+		{name: "national without default", number: "0912345678", wantErr: true},
+		{name: "too short", number: "+841234", wantErr: true},
+		{name: "too long", number: "+8491234567890123", wantErr: true},
+		{name: "country code 0", number: "+0912345678", wantErr: true},
+		{name: "letters", number: "+84912abc678", wantErr: true},
+		{name: "empty", number: "", defaultCountryCode: "84", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeE164(tt.number, tt.defaultCountryCode)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NormalizeE164(%q, %q) error = %v, wantErr %v", tt.number, tt.defaultCountryCode, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("NormalizeE164(%q, %q) = %q, want %q", tt.number, tt.defaultCountryCode, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSplitSMS(t *testing.T) {
+	tests := []struct {
+		name         string
+		message      string
+		wantEncoding SMSEncoding
+		// the length of every segment, in septets for GSM-7 and UTF-16 code units for UCS-2
+		wantLengths []int
+	}{
+		{name: "GSM-7 single", message: strings.Repeat("a", 160), wantEncoding: SMSEncodingGSM7, wantLengths: []int{160}},
+		{name: "GSM-7 two segments", message: strings.Repeat("a", 161), wantEncoding: SMSEncodingGSM7, wantLengths: []int{153, 8}},
+		{name: "GSM-7 full segments", message: strings.Repeat("a", 306), wantEncoding: SMSEncodingGSM7, wantLengths: []int{153, 153}},
+		{name: "GSM-7 escaped single", message: strings.Repeat("€", 80), wantEncoding: SMSEncodingGSM7, wantLengths: []int{160}},
+		{name: "GSM-7 escaped over", message: strings.Repeat("a", 159) + "€", wantEncoding: SMSEncodingGSM7, wantLengths: []int{153, 8}},
+		// the escape sequence at septets 153 and 154 moves to the second segment whole
+		{name: "GSM-7 escape not split", message: strings.Repeat("a", 152) + "{" + strings.Repeat("a", 10), wantEncoding: SMSEncodingGSM7, wantLengths: []int{152, 12}},
+		{name: "accents outside GSM-7", message: "Chào các bạn", wantEncoding: SMSEncodingUCS2, wantLengths: []int{12}},
+		{name: "UCS-2 single", message: strings.Repeat("ạ", 70), wantEncoding: SMSEncodingUCS2, wantLengths: []int{70}},
+		{name: "UCS-2 two segments", message: strings.Repeat("ạ", 71), wantEncoding: SMSEncodingUCS2, wantLengths: []int{67, 4}},
+		{name: "UCS-2 forced by one character", message: strings.Repeat("a", 100) + "ạ", wantEncoding: SMSEncodingUCS2, wantLengths: []int{67, 34}},
+		// each emoji is a surrogate pair, the 34th would straddle code units 67 and 68
+		{name: "UCS-2 surrogate pair not split", message: strings.Repeat("😀", 36), wantEncoding: SMSEncodingUCS2, wantLengths: []int{66, 6}},
+		{name: "empty", message: "", wantEncoding: SMSEncodingGSM7, wantLengths: []int{0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			segments, encoding := SplitSMS(tt.message)
+			if encoding != tt.wantEncoding {
+				t.Errorf("encoding = %s, want %s", encoding, tt.wantEncoding)
+			}
+
+			lengths := make([]int, len(segments))
+			for i, segment := range segments {
+				lengths[i] = smsLength(segment, encoding)
+			}
+			if !slices.Equal(lengths, tt.wantLengths) {
+				t.Errorf("segment lengths = %v, want %v", lengths, tt.wantLengths)
+			}
+
+			if joined := strings.Join(segments, ""); joined != tt.message {
+				t.Errorf("segments join to %q, want %q", joined, tt.message)
+			}
+		})
+	}
+}
+
+func smsLength(segment string, encoding SMSEncoding) int {
+	if encoding == SMSEncodingUCS2 {
+		return len(utf16.Encode([]rune(segment)))
+	}
+
+	length := 0
+	for _, r := range segment {
+		length += gsm7Septets[r]
+	}
+	return length
+}
@@ -0,0 +1,390 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package providers
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/config"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/services"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/logger"
+	"go.uber.org/zap"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type SMTPTLSMode string
+
+const (
+	// SMTPTLSModeStartTLS upgrades the connection with STARTTLS and fails if the server doesn't offer it.
+	SMTPTLSModeStartTLS SMTPTLSMode = "starttls"
+	// SMTPTLSModeImplicit connects over TLS, usually on port 465.
+	SMTPTLSModeImplicit SMTPTLSMode = "implicit"
+	// SMTPTLSModeNone sends in plain text, only meant for a local SMTP sink.
+	SMTPTLSModeNone SMTPTLSMode = "none"
+)
+
+var (
+	headerName = regexp.MustCompile(`^[!-9;-~]+$`)
+	messageID  = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+	// reservedHeaders are set by the provider and can't be overridden through the metadata.
+	reservedHeaders = map[string]bool{
+		"From": true, "Sender": true, "To": true, "Cc": true, "Bcc": true, "Subject": true, "Date": true,
+		"Message-Id": true, "X-Notification-Id": true, "Mime-Version": true, "Content-Type": true,
+		"Content-Transfer-Encoding": true, "Return-Path": true,
+	}
+)
+
+// SMTPProvider sends email notifications through an SMTP server. The title is the subject and the message
+// the plain-text body; the HTML alternative, additional headers and attachments are read from the metadata,
+// see email.
+type SMTPProvider struct {
+	host        string
+	port        int
+	username    string
+	password    string
+	from        *mail.Address
+	tlsMode     SMTPTLSMode
+	tlsConfig   *tls.Config
+	timeout     time.Duration
+	attachments *attachmentFetcher
+	logger      *zap.Logger
+}
+
+func NewSMTPProvider(logger *zap.Logger) (*SMTPProvider, error) {
+	host := config.GetString("SMTP_HOST", "localhost")
+
+	from, err := mail.ParseAddress(config.GetString("SMTP_FROM", "Notification Service <no-reply@localhost>"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SMTP_FROM: %w", err)
+	}
+
+	tlsMode := SMTPTLSMode(config.GetString("SMTP_TLS_MODE", string(SMTPTLSModeStartTLS)))
+	if tlsMode != SMTPTLSModeStartTLS && tlsMode != SMTPTLSModeImplicit && tlsMode != SMTPTLSModeNone {
+		return nil, fmt.Errorf("unknown SMTP_TLS_MODE %q, expected %q, %q or %q", tlsMode, SMTPTLSModeStartTLS, SMTPTLSModeImplicit, SMTPTLSModeNone)
+	}
+
+	timeout := time.Duration(config.GetInt("SMTP_TIMEOUT_MS", 10000)) * time.Millisecond
+
+	return &SMTPProvider{
+		host:     host,
+		port:     config.GetInt("SMTP_PORT", 587),
+		username: config.GetString("SMTP_USERNAME", ""),
+		password: config.GetString("SMTP_PASSWORD", ""),
+		from:     from,
+		tlsMode:  tlsMode,
+		tlsConfig: &tls.Config{
+			ServerName:         host,
+			MinVersion:         tls.VersionTLS12,
+			InsecureSkipVerify: config.GetBool("SMTP_TLS_INSECURE_SKIP_VERIFY", false),
+		},
+		timeout: timeout,
+		attachments: &attachmentFetcher{
+			client:       newOutboundClient(timeout, config.GetBool("SMTP_ATTACHMENT_ALLOW_PRIVATE_NETWORKS", false)),
+			maxBytes:     int64(config.GetInt("SMTP_ATTACHMENT_MAX_BYTES", 10<<20)),
+			allowedHosts: parseHosts(config.GetString("SMTP_ATTACHMENT_ALLOWED_HOSTS", "")),
+		},
+		logger: logger,
+	}, nil
+}
+
+func (p *SMTPProvider) Name() string {
+	return "smtp"
+}
+
+// Send delivers notification to its recipient. Errors that a later attempt won't fix, an invalid
+// recipient or metadata, a 5xx reply to the recipient or the data, or an attachment that can't be
+// downloaded, are returned wrapped with services.Permanent; any other error with services.Retryable.
+func (p *SMTPProvider) Send(ctx context.Context, notification *models.Notification) error {
+	to, err := mail.ParseAddress(notification.Recipient)
+	if err != nil {
+		return services.Permanent(fmt.Errorf("invalid recipient: %w", err))
+	}
+
+	content, err := parseEmail(notification)
+	if err != nil {
+		return services.Permanent(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.timeout)
+	defer cancel()
+
+	attachments, err := p.attachments.fetchAll(ctx, content.attachments)
+	if err != nil {
+		return err
+	}
+
+	msg, err := p.buildMessage(notification, to, content, attachments)
+	if err != nil {
+		return services.Permanent(err)
+	}
+
+	if err := p.deliver(ctx, to.Address, msg); err != nil {
+		return err
+	}
+
+	logger.FromContext(ctx, p.logger).Debug("Email delivered", zap.String("id", notification.ID), zap.Int("bytes", len(msg)), zap.Int("attachments", len(attachments)))
+
+	return nil
+}
+
+func (p *SMTPProvider) deliver(ctx context.Context, to string, msg []byte) error {
+	addr := net.JoinHostPort(p.host, strconv.Itoa(p.port))
+
+	var (
+		conn net.Conn
+		err  error
+	)
+	if p.tlsMode == SMTPTLSModeImplicit {
+		conn, err = (&tls.Dialer{Config: p.tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = (&net.Dialer{}).DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return services.Retryable(fmt.Errorf("connect to %s: %w", addr, err))
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	// the SMTP client has no context support, unblock it through the connection
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+	stop := context.AfterFunc(ctx, func() {
+		_ = conn.SetDeadline(time.Now())
+	})
+	defer stop()
+
+	client, err := smtp.NewClient(conn, p.host)
+	if err != nil {
+		return services.Retryable(fmt.Errorf("smtp greeting: %w", err))
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	if p.tlsMode == SMTPTLSModeStartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return services.Retryable(errors.New("smtp server does not support STARTTLS"))
+		}
+		if err := client.StartTLS(p.tlsConfig); err != nil {
+			return services.Retryable(fmt.Errorf("smtp starttls: %w", err))
+		}
+	}
+
+	if p.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", p.username, p.password, p.host)); err != nil {
+			return services.Retryable(fmt.Errorf("smtp auth: %w", err))
+		}
+	}
+
+	if err := client.Mail(p.from.Address); err != nil {
+		return services.Retryable(fmt.Errorf("smtp mail from: %w", err))
+	}
+	if err := client.Rcpt(to); err != nil {
+		return classifyReply("smtp rcpt to", err)
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return classifyReply("smtp data", err)
+	}
+	if _, err := w.Write(msg); err != nil {
+		return services.Retryable(fmt.Errorf("smtp data: %w", err))
+	}
+	if err := w.Close(); err != nil {
+		return classifyReply("smtp data", err)
+	}
+
+	// the message is accepted once the data is, a failed quit doesn't make it undelivered
+	_ = client.Quit()
+
+	return nil
+}
+
+// classifyReply makes a 5xx reply of the server, a rejection of the recipient or the message, permanent.
+func classifyReply(stage string, err error) error {
+	err = fmt.Errorf("%s: %w", stage, err)
+
+	var reply *textproto.Error
+	if errors.As(err, &reply) && reply.Code >= 500 {
+		return services.Permanent(err)
+	}
+	return services.Retryable(err)
+}
+
+func (p *SMTPProvider) buildMessage(notification *models.Notification, to *mail.Address, content *email, attachments []attachment) ([]byte, error) {
+	body := textEntity("text/plain", notification.Message)
+
+	if content.html != "" {
+		var err error
+		if body, err = multipartEntity("alternative", body, textEntity("text/html", content.html)); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(attachments) > 0 {
+		parts := []mimeEntity{body}
+		for _, a := range attachments {
+			parts = append(parts, attachmentEntity(a))
+		}
+
+		var err error
+		if body, err = multipartEntity("mixed", parts...); err != nil {
+			return nil, err
+		}
+	}
+
+	// the ID of a consumed notification is not validated, keep it out of the header if it could break it
+	id := notification.ID
+	if !messageID.MatchString(id) {
+		id = uuid.New().String()
+	}
+
+	var buf bytes.Buffer
+
+	writeHeader(&buf, "From", p.from.String())
+	writeHeader(&buf, "To", to.String())
+	if notification.Title != "" {
+		writeHeader(&buf, "Subject", mime.QEncoding.Encode("utf-8", notification.Title))
+	}
+	writeHeader(&buf, "Date", time.Now().Format(time.RFC1123Z))
+	writeHeader(&buf, "Message-ID", fmt.Sprintf("<%s@%s>", id, domain(p.from.Address)))
+	writeHeader(&buf, "X-Notification-ID", id)
+
+	names := make([]string, 0, len(content.headers))
+	for name := range content.headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		value := content.headers[name]
+		if !headerName.MatchString(name) || reservedHeaders[textproto.CanonicalMIMEHeaderKey(name)] {
+			return nil, fmt.Errorf("header %q can't be set", name)
+		}
+		if strings.ContainsAny(value, "\r\n") {
+			return nil, fmt.Errorf("value of header %q contains a line break", name)
+		}
+		writeHeader(&buf, name, mime.QEncoding.Encode("utf-8", value))
+	}
+
+	writeHeader(&buf, "MIME-Version", "1.0")
+	for _, name := range []string{"Content-Type", "Content-Transfer-Encoding"} {
+		if value := body.header.Get(name); value != "" {
+			writeHeader(&buf, name, value)
+		}
+	}
+	buf.WriteString("\r\n")
+	buf.Write(body.body)
+
+	return buf.Bytes(), nil
+}
+
+// mimeEntity is a MIME entity with its body already encoded as its header says.
+type mimeEntity struct {
+	header textproto.MIMEHeader
+	body   []byte
+}
+
+func textEntity(mediaType, text string) mimeEntity {
+	var buf bytes.Buffer
+	w := quotedprintable.NewWriter(&buf)
+	_, _ = w.Write([]byte(text))
+	_ = w.Close()
+
+	return mimeEntity{
+		header: textproto.MIMEHeader{
+			"Content-Type":              {mime.FormatMediaType(mediaType, map[string]string{"charset": "utf-8"})},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		},
+		body: buf.Bytes(),
+	}
+}
+
+func attachmentEntity(a attachment) mimeEntity {
+	encoded := base64.StdEncoding.EncodeToString(a.data)
+
+	var buf bytes.Buffer
+	for len(encoded) > 76 {
+		buf.WriteString(encoded[:76])
+		buf.WriteString("\r\n")
+		encoded = encoded[76:]
+	}
+	buf.WriteString(encoded)
+
+	mediaType, params, err := mime.ParseMediaType(a.contentType)
+	if err != nil {
+		mediaType, params = "application/octet-stream", map[string]string{}
+	}
+	params["name"] = a.filename
+
+	return mimeEntity{
+		header: textproto.MIMEHeader{
+			"Content-Type":              {mime.FormatMediaType(mediaType, params)},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": a.filename})},
+			"Content-Transfer-Encoding": {"base64"},
+		},
+		body: buf.Bytes(),
+	}
+}
+
+func multipartEntity(subtype string, parts ...mimeEntity) (mimeEntity, error) {
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+
+	for _, part := range parts {
+		pw, err := w.CreatePart(part.header)
+		if err != nil {
+			return mimeEntity{}, fmt.Errorf("create %s part: %w", subtype, err)
+		}
+		if _, err := pw.Write(part.body); err != nil {
+			return mimeEntity{}, fmt.Errorf("write %s part: %w", subtype, err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		return mimeEntity{}, fmt.Errorf("close %s parts: %w", subtype, err)
+	}
+
+	return mimeEntity{
+		header: textproto.MIMEHeader{
+			"Content-Type": {mime.FormatMediaType("multipart/"+subtype, map[string]string{"boundary": w.Boundary()})},
+		},
+		body: buf.Bytes(),
+	}, nil
+}
+
+func writeHeader(buf *bytes.Buffer, name, value string) {
+	buf.WriteString(name)
+	buf.WriteString(": ")
+	buf.WriteString(value)
+	buf.WriteString("\r\n")
+}
+
+func domain(address string) string {
+	if i := strings.LastIndex(address, "@"); i >= 0 {
+		return address[i+1:]
+	}
+	return "localhost"
+}
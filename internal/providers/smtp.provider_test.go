@@ -0,0 +1,247 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package providers
+
+import (
+	"context"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/constants"
+	"go.uber.org/zap"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+)
+
+// smtpSink is a local SMTP server accepting one message per connection, replying to RCPT TO and to the end
+// of DATA with the configured replies.
+type smtpSink struct {
+	listener  net.Listener
+	rcptReply string
+	dataReply string
+	messages  chan string
+}
+
+func newSMTPSink(t *testing.T, rcptReply, dataReply string) *smtpSink {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	s := &smtpSink{listener: listener, rcptReply: rcptReply, dataReply: dataReply, messages: make(chan string, 1)}
+	go s.serve()
+
+	return s
+}
+
+func (s *smtpSink) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+func (s *smtpSink) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *smtpSink) handle(conn net.Conn) {
+	defer func() {
+		_ = conn.Close()
+	}()
+
+	text := textproto.NewConn(conn)
+	reply := func(line string) bool {
+		return text.PrintfLine("%s", line) == nil
+	}
+
+	if !reply("220 localhost ESMTP sink") {
+		return
+	}
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+
+		switch verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0]); verb {
+		case "EHLO", "HELO":
+			reply("250-localhost")
+			reply("250 8BITMIME")
+		case "MAIL":
+			reply("250 OK")
+		case "RCPT":
+			reply(s.rcptReply)
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			if strings.HasPrefix(s.dataReply, "250") {
+				s.messages <- string(data)
+			}
+			reply(s.dataReply)
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func TestSMTPProviderSend(t *testing.T) {
+	tests := []struct {
+		name      string
+		recipient string
+		rcptReply string
+		dataReply string
+		tlsMode   SMTPTLSMode
+		want      errorKind
+	}{
+		{name: "delivered", recipient: "Kiet <kiet@example.com>", rcptReply: "250 OK", dataReply: "250 Queued", tlsMode: SMTPTLSModeNone},
+		{name: "invalid recipient", recipient: "not an address", rcptReply: "250 OK", dataReply: "250 Queued", tlsMode: SMTPTLSModeNone, want: kindPermanent},
+		{name: "recipient rejected", recipient: "kiet@example.com", rcptReply: "550 No such user", dataReply: "250 Queued", tlsMode: SMTPTLSModeNone, want: kindPermanent},
+		{name: "recipient deferred", recipient: "kiet@example.com", rcptReply: "450 Mailbox busy", dataReply: "250 Queued", tlsMode: SMTPTLSModeNone, want: kindRetryable},
+		{name: "message rejected", recipient: "kiet@example.com", rcptReply: "250 OK", dataReply: "554 Message refused", tlsMode: SMTPTLSModeNone, want: kindPermanent},
+		{name: "message deferred", recipient: "kiet@example.com", rcptReply: "250 OK", dataReply: "451 Try again later", tlsMode: SMTPTLSModeNone, want: kindRetryable},
+		{name: "STARTTLS not offered", recipient: "kiet@example.com", rcptReply: "250 OK", dataReply: "250 Queued", tlsMode: SMTPTLSModeStartTLS, want: kindRetryable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := newSMTPSink(t, tt.rcptReply, tt.dataReply)
+			p := newTestSMTPProvider(sink.port(), tt.tlsMode)
+
+			err := p.Send(context.Background(), &models.Notification{
+				ID:        "n-1",
+				Type:      models.NotificationTypeEmail,
+				Recipient: tt.recipient,
+				Title:     "Welcome",
+				Message:   "Hello Kiet",
+				MetaData: map[string]interface{}{
+					string(constants.MetaDataHTML): "<p>Hello Kiet</p>",
+				},
+			})
+			if got := classify(err); got != tt.want {
+				t.Fatalf("Send error = %v, classified %q, want %q", err, got, tt.want)
+			}
+			if err != nil {
+				return
+			}
+
+			msg, err := mail.ReadMessage(strings.NewReader(<-sink.messages))
+			if err != nil {
+				t.Fatalf("read delivered message: %v", err)
+			}
+			if got := msg.Header.Get("Subject"); got != "Welcome" {
+				t.Errorf("Subject = %q", got)
+			}
+			if got := msg.Header.Get("X-Notification-Id"); got != "n-1" {
+				t.Errorf("X-Notification-Id = %q", got)
+			}
+			if got := msg.Header.Get("Content-Type"); !strings.HasPrefix(got, "multipart/alternative") {
+				t.Errorf("Content-Type = %q", got)
+			}
+		})
+	}
+}
+
+func TestSMTPProviderUnreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	_ = listener.Close()
+
+	p := newTestSMTPProvider(port, SMTPTLSModeNone)
+
+	err = p.Send(context.Background(), &models.Notification{ID: "n-1", Recipient: "kiet@example.com", Title: "Welcome", Message: "Hello"})
+	if got := classify(err); got != kindRetryable {
+		t.Errorf("Send error = %v, classified %q, want %q", err, got, kindRetryable)
+	}
+}
+
+func TestSMTPProviderAttachments(t *testing.T) {
+	files := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("report"))
+	}))
+	defer files.Close()
+
+	tests := []struct {
+		name         string
+		allowedHosts string
+		allowPrivate bool
+		want         errorKind
+	}{
+		{name: "no allowlist", allowPrivate: true, want: kindPermanent},
+		{name: "host not allowed", allowedHosts: "files.example.com", allowPrivate: true, want: kindPermanent},
+		{name: "loopback refused", allowedHosts: "127.0.0.1", want: kindPermanent},
+		{name: "attached", allowedHosts: "127.0.0.1", allowPrivate: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sink := newSMTPSink(t, "250 OK", "250 Queued")
+			p := newTestSMTPProvider(sink.port(), SMTPTLSModeNone)
+			p.attachments = &attachmentFetcher{
+				client:       newOutboundClient(5*time.Second, tt.allowPrivate),
+				maxBytes:     1 << 20,
+				allowedHosts: parseHosts(tt.allowedHosts),
+			}
+
+			err := p.Send(context.Background(), &models.Notification{
+				ID:        "n-1",
+				Recipient: "kiet@example.com",
+				Title:     "Report",
+				Message:   "Attached",
+				MetaData: map[string]interface{}{
+					string(constants.MetaDataAttachments): []interface{}{files.URL + "/report.txt"},
+				},
+			})
+			if got := classify(err); got != tt.want {
+				t.Fatalf("Send error = %v, classified %q, want %q", err, got, tt.want)
+			}
+			if err != nil {
+				return
+			}
+
+			if msg := <-sink.messages; !strings.Contains(msg, "filename=report.txt") || !strings.Contains(msg, "cmVwb3J0") {
+				t.Errorf("message has no report.txt attachment:\n%s", msg)
+			}
+		})
+	}
+}
+
+func newTestSMTPProvider(port int, tlsMode SMTPTLSMode) *SMTPProvider {
+	return &SMTPProvider{
+		host:    "127.0.0.1",
+		port:    port,
+		from:    &mail.Address{Name: "Notify", Address: "no-reply@example.com"},
+		tlsMode: tlsMode,
+		timeout: 5 * time.Second,
+		attachments: &attachmentFetcher{
+			client:   newOutboundClient(5*time.Second, false),
+			maxBytes: 1 << 20,
+		},
+		logger: zap.NewNop(),
+	}
+}
@@ -0,0 +1,166 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/config"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/services"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// twilioPermanentCodes are the error codes of the Twilio API that are about the message rather than the
+// account or the service, see https://www.twilio.com/docs/api/errors.
+var twilioPermanentCodes = map[int]bool{
+	21211: true, // invalid 'To' phone number
+	21217: true, // phone number does not appear to be valid
+	21408: true, // permission to send an SMS has not been enabled for the region
+	21602: true, // message body is required
+	21610: true, // the recipient unsubscribed
+	21612: true, // the 'To' number is not reachable from the 'From' number
+	21614: true, // 'To' number is not a valid mobile number
+	21617: true, // the message body exceeds the 1600 character limit
+	21635: true, // 'To' number cannot be a landline
+}
+
+// twilioRetryableCodes are the error codes of the Twilio API returned with a 4xx status that may succeed
+// later, once a rate limit is lifted or the account fixed.
+var twilioRetryableCodes = map[int]bool{
+	20003: true, // authentication error
+	20404: true, // resource not found, e.g. an unknown account
+	20429: true, // too many requests
+	21606: true, // the 'From' number is not a valid, SMS-capable number of the account
+	21611: true, // the 'From' number has exceeded its queue of unsent messages
+}
+
+// TwilioError is an error returned by a Twilio-compatible API.
+type TwilioError struct {
+	StatusCode int    `json:"status"`
+	Code       int    `json:"code"`
+	Message    string `json:"message"`
+	MoreInfo   string `json:"more_info"`
+}
+
+func (e *TwilioError) Error() string {
+	if e.Code == 0 {
+		return fmt.Sprintf("twilio: %d %s", e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("twilio: %d error %d: %s", e.StatusCode, e.Code, e.Message)
+}
+
+// TwilioGateway sends SMS through the Messages resource of the Twilio REST API, or of any API compatible
+// with it, e.g. a local fake server, at SMS_BASE_URL. Messages are sent from SMS_FROM or, when it is set,
+// through the messaging service SMS_MESSAGING_SERVICE_SID.
+type TwilioGateway struct {
+	baseURL             string
+	accountSID          string
+	authToken           string
+	from                string
+	messagingServiceSID string
+	client              *http.Client
+}
+
+func NewTwilioGateway() (*TwilioGateway, error) {
+	g := &TwilioGateway{
+		baseURL:             strings.TrimSuffix(config.GetString("SMS_BASE_URL", "https://api.twilio.com"), "/"),
+		accountSID:          config.GetString("SMS_ACCOUNT_SID", ""),
+		authToken:           config.GetString("SMS_AUTH_TOKEN", ""),
+		from:                config.GetString("SMS_FROM", ""),
+		messagingServiceSID: config.GetString("SMS_MESSAGING_SERVICE_SID", ""),
+		client: &http.Client{
+			Timeout: time.Duration(config.GetInt("SMS_TIMEOUT_MS", 10000)) * time.Millisecond,
+		},
+	}
+
+	if g.accountSID == "" || g.authToken == "" {
+		return nil, errors.New("SMS_ACCOUNT_SID and SMS_AUTH_TOKEN are required")
+	}
+	if g.from == "" && g.messagingServiceSID == "" {
+		return nil, errors.New("SMS_FROM or SMS_MESSAGING_SERVICE_SID is required")
+	}
+
+	return g, nil
+}
+
+func (g *TwilioGateway) Name() string {
+	return "twilio"
+}
+
+// SendSMS creates a message and returns its SID. Network errors, 5xx responses and the codes of
+// twilioRetryableCodes are retryable; the codes of twilioPermanentCodes and other 4xx responses permanent,
+// except for 401 and 403, which come from the account's configuration.
+func (g *TwilioGateway) SendSMS(ctx context.Context, to, body string) (string, error) {
+	form := url.Values{}
+	form.Set("To", to)
+	form.Set("Body", body)
+	if g.messagingServiceSID != "" {
+		form.Set("MessagingServiceSid", g.messagingServiceSID)
+	} else {
+		form.Set("From", g.from)
+	}
+
+	target := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", g.baseURL, url.PathEscape(g.accountSID))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("create request: %w", err)
+	}
+	req.SetBasicAuth(g.accountSID, g.authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return "", services.Retryable(fmt.Errorf("twilio: %w", err))
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return "", services.Retryable(fmt.Errorf("twilio: read response: %w", err))
+	}
+
+	if resp.StatusCode >= 300 {
+		apiErr := &TwilioError{}
+		if json.Unmarshal(data, apiErr) != nil || apiErr.Message == "" {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+		apiErr.StatusCode = resp.StatusCode
+
+		return "", classifyTwilioError(apiErr)
+	}
+
+	// the message was created even if its SID can't be read, failing would have it sent again
+	var message struct {
+		SID string `json:"sid"`
+	}
+	_ = json.Unmarshal(data, &message)
+
+	return message.SID, nil
+}
+
+func classifyTwilioError(err *TwilioError) error {
+	switch {
+	case twilioPermanentCodes[err.Code]:
+		return services.Permanent(err)
+	case twilioRetryableCodes[err.Code]:
+		return services.Retryable(err)
+	case err.StatusCode >= 500, err.StatusCode == http.StatusTooManyRequests,
+		err.StatusCode == http.StatusUnauthorized, err.StatusCode == http.StatusForbidden:
+		return services.Retryable(err)
+	default:
+		return services.Permanent(err)
+	}
+}
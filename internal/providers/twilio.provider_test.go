@@ -0,0 +1,90 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package providers
+
+import (
+	"context"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/services"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTwilioGatewaySendSMS(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		wantSID       string
+		wantErr       bool
+		wantPermanent bool
+	}{
+		{name: "created", status: http.StatusCreated, body: `{"sid": "SM123"}`, wantSID: "SM123"},
+		{name: "created without SID", status: http.StatusCreated, body: `not json`},
+		{name: "invalid recipient", status: http.StatusBadRequest, body: `{"code": 21211, "message": "Invalid 'To' Phone Number"}`, wantErr: true, wantPermanent: true},
+		{name: "unsubscribed", status: http.StatusBadRequest, body: `{"code": 21610, "message": "Attempt to send to unsubscribed recipient"}`, wantErr: true, wantPermanent: true},
+		{name: "invalid sender", status: http.StatusBadRequest, body: `{"code": 21606, "message": "The From phone number is not a valid, SMS-capable number"}`, wantErr: true},
+		{name: "unknown code", status: http.StatusBadRequest, body: `{"code": 21000, "message": "Something about the message"}`, wantErr: true, wantPermanent: true},
+		{name: "unauthorized", status: http.StatusUnauthorized, body: `{"code": 20003, "message": "Authenticate"}`, wantErr: true},
+		{name: "forbidden without body", status: http.StatusForbidden, wantErr: true},
+		{name: "rate limited", status: http.StatusTooManyRequests, body: `{"code": 20429, "message": "Too Many Requests"}`, wantErr: true},
+		{name: "server error", status: http.StatusServiceUnavailable, body: `<html>unavailable</html>`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/2010-04-01/Accounts/AC1/Messages.json" {
+					t.Errorf("path = %s", r.URL.Path)
+				}
+				if user, password, ok := r.BasicAuth(); !ok || user != "AC1" || password != "token" {
+					t.Errorf("basic auth = %s:%s", user, password)
+				}
+				if err := r.ParseForm(); err != nil {
+					t.Errorf("parse form: %v", err)
+				}
+				if r.PostForm.Get("To") != "+84912345678" || r.PostForm.Get("From") != "+15005550006" || r.PostForm.Get("Body") != "hello" {
+					t.Errorf("form = %v", r.PostForm)
+				}
+
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			gateway := &TwilioGateway{
+				baseURL:    server.URL,
+				accountSID: "AC1",
+				authToken:  "token",
+				from:       "+15005550006",
+				client:     server.Client(),
+			}
+
+			sid, err := gateway.SendSMS(context.Background(), "+84912345678", "hello")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SendSMS error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && services.IsPermanent(err) != tt.wantPermanent {
+				t.Errorf("IsPermanent(%v) = %v, want %v", err, services.IsPermanent(err), tt.wantPermanent)
+			}
+			if sid != tt.wantSID {
+				t.Errorf("SID = %q, want %q", sid, tt.wantSID)
+			}
+		})
+	}
+}
+
+func TestTwilioGatewayUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	gateway := &TwilioGateway{baseURL: server.URL, accountSID: "AC1", authToken: "token", from: "+15005550006", client: http.DefaultClient}
+
+	_, err := gateway.SendSMS(context.Background(), "+84912345678", "hello")
+	if err == nil || services.IsPermanent(err) {
+		t.Errorf("SendSMS error = %v, want a retryable error", err)
+	}
+}
@@ -0,0 +1,177 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package providers
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/config"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/services"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/logger"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	HeaderWebhookID        = "X-Webhook-ID"
+	HeaderWebhookTimestamp = "X-Webhook-Timestamp"
+	HeaderWebhookSignature = "X-Webhook-Signature"
+)
+
+// webhookPayload is the JSON body posted to a webhook.
+type webhookPayload struct {
+	ID        string                  `json:"id"`
+	Type      models.NotificationType `json:"type"`
+	Title     string                  `json:"title"`
+	Message   string                  `json:"message"`
+	MetaData  map[string]interface{}  `json:"meta_data,omitempty"`
+	Timestamp time.Time               `json:"timestamp"`
+}
+
+// WebhookProvider posts notifications as JSON to the URL of their recipient, signed with
+// WEBHOOK_SIGNING_SECRET so that the receiver can check they come from this service: the X-Webhook-Signature
+// header is "sha256=" followed by the hex HMAC-SHA256 of the X-Webhook-Timestamp header, a dot and the body.
+// With WEBHOOK_ALLOWED_HOSTS set, only URLs on those hosts are accepted. Whether or not it is set, URLs
+// resolving to loopback, private or link-local addresses are refused unless WEBHOOK_ALLOW_PRIVATE_NETWORKS
+// is true, and redirects are not followed.
+type WebhookProvider struct {
+	secret       []byte
+	allowedHosts map[string]bool
+	client       *http.Client
+	logger       *zap.Logger
+}
+
+func NewWebhookProvider(logger *zap.Logger) *WebhookProvider {
+	secret := config.GetString("WEBHOOK_SIGNING_SECRET", "")
+	if secret == "" {
+		logger.Warn("WEBHOOK_SIGNING_SECRET is not set, webhook notifications will be parked until it is")
+	}
+
+	return &WebhookProvider{
+		secret:       []byte(secret),
+		allowedHosts: parseHosts(config.GetString("WEBHOOK_ALLOWED_HOSTS", "")),
+		client: newOutboundClient(
+			time.Duration(config.GetInt("WEBHOOK_TIMEOUT_MS", 10000))*time.Millisecond,
+			config.GetBool("WEBHOOK_ALLOW_PRIVATE_NETWORKS", false),
+		),
+		logger: logger,
+	}
+}
+
+func (p *WebhookProvider) Name() string {
+	return "webhook"
+}
+
+// Send posts the notification. Network errors, 408, 429 and 5xx responses are retryable; URLs resolving to
+// an address that isn't public and other responses that are not 2xx, including redirects, permanent.
+func (p *WebhookProvider) Send(ctx context.Context, notification *models.Notification) error {
+	if len(p.secret) == 0 {
+		return services.Retryable(errors.New("WEBHOOK_SIGNING_SECRET is not set"))
+	}
+
+	target, err := checkURL(notification.Recipient, p.allowedHosts)
+	if err != nil {
+		return services.Permanent(err)
+	}
+
+	now := time.Now().UTC()
+	body, err := json.Marshal(&webhookPayload{
+		ID:        notification.ID,
+		Type:      notification.Type,
+		Title:     notification.Title,
+		Message:   notification.Message,
+		MetaData:  notification.MetaData,
+		Timestamp: now,
+	})
+	if err != nil {
+		return services.Permanent(fmt.Errorf("encode webhook payload: %w", err))
+	}
+
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.String(), bytes.NewReader(body))
+	if err != nil {
+		return services.Permanent(fmt.Errorf("webhook %s: %w", target.Host, err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderWebhookID, notification.ID)
+	req.Header.Set(HeaderWebhookTimestamp, timestamp)
+	req.Header.Set(HeaderWebhookSignature, SignWebhook(p.secret, timestamp, body))
+
+	// errors name the host only, webhook URLs often carry a secret in their path
+	resp, err := p.client.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		if errors.Is(err, errForbiddenAddress) {
+			return services.Permanent(fmt.Errorf("webhook %s: %w", target.Host, err))
+		}
+		return services.Retryable(fmt.Errorf("webhook %s: %w", target.Host, err))
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	// drained so that the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	switch {
+	case resp.StatusCode >= 500, resp.StatusCode == http.StatusTooManyRequests, resp.StatusCode == http.StatusRequestTimeout:
+		return services.Retryable(fmt.Errorf("webhook %s: %s", target.Host, resp.Status))
+	case resp.StatusCode >= 300:
+		return services.Permanent(fmt.Errorf("webhook %s: %s", target.Host, resp.Status))
+	}
+
+	logger.FromContext(ctx, p.logger).Debug("Webhook delivered", zap.String("id", notification.ID), zap.String("host", target.Host), zap.Int("status", resp.StatusCode))
+
+	return nil
+}
+
+// SignWebhook returns the X-Webhook-Signature of a webhook body sent at timestamp, in Unix seconds.
+func SignWebhook(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// checkURL parses an http or https URL and, when allowedHosts is not empty, checks that its host is one of
+// them.
+func checkURL(raw string, allowedHosts map[string]bool) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, errors.New("recipient must be an http or https URL")
+	}
+	if len(allowedHosts) > 0 && !allowedHosts[strings.ToLower(u.Hostname())] {
+		return nil, fmt.Errorf("host %s is not allowed", u.Hostname())
+	}
+	return u, nil
+}
+
+// parseHosts parses a comma separated list of host names.
+func parseHosts(list string) map[string]bool {
+	hosts := make(map[string]bool)
+	for _, h := range strings.Split(list, ",") {
+		if h = strings.TrimSpace(h); h != "" {
+			hosts[strings.ToLower(h)] = true
+		}
+	}
+	return hosts
+}
@@ -0,0 +1,105 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package providers
+
+import (
+	"context"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/services"
+	"go.uber.org/zap"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestWebhookProviderSend(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		allowPrivate  bool
+		wantErr       bool
+		wantPermanent bool
+	}{
+		{name: "delivered", status: http.StatusNoContent, allowPrivate: true},
+		{name: "loopback refused", status: http.StatusNoContent, wantErr: true, wantPermanent: true},
+		{name: "server error", status: http.StatusBadGateway, allowPrivate: true, wantErr: true},
+		{name: "rate limited", status: http.StatusTooManyRequests, allowPrivate: true, wantErr: true},
+		{name: "redirect", status: http.StatusFound, allowPrivate: true, wantErr: true, wantPermanent: true},
+		{name: "rejected", status: http.StatusBadRequest, allowPrivate: true, wantErr: true, wantPermanent: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var signature, expected string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				signature = r.Header.Get(HeaderWebhookSignature)
+				expected = SignWebhook([]byte("secret"), r.Header.Get(HeaderWebhookTimestamp), body)
+				if tt.status == http.StatusFound {
+					w.Header().Set("Location", "http://169.254.169.254/")
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			p := &WebhookProvider{
+				secret:       []byte("secret"),
+				allowedHosts: parseHosts(""),
+				client:       newOutboundClient(5*time.Second, tt.allowPrivate),
+				logger:       zap.NewNop(),
+			}
+
+			err := p.Send(context.Background(), &models.Notification{
+				ID:        "n-1",
+				Type:      models.NotificationTypeWebhook,
+				Recipient: server.URL + "/hook",
+				Message:   "hello",
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Send error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && services.IsPermanent(err) != tt.wantPermanent {
+				t.Errorf("IsPermanent(%v) = %v, want %v", err, services.IsPermanent(err), tt.wantPermanent)
+			}
+			if !tt.wantErr && signature != expected {
+				t.Errorf("signature = %q, want %q", signature, expected)
+			}
+		})
+	}
+}
+
+func TestCheckPublicAddress(t *testing.T) {
+	tests := []struct {
+		address string
+		wantErr bool
+	}{
+		{address: "93.184.216.34:443"},
+		{address: "[2606:4700::1111]:443"},
+		{address: "127.0.0.1:80", wantErr: true},
+		{address: "[::1]:80", wantErr: true},
+		{address: "10.0.0.1:80", wantErr: true},
+		{address: "172.16.5.4:80", wantErr: true},
+		{address: "192.168.1.1:80", wantErr: true},
+		{address: "169.254.169.254:80", wantErr: true},
+		{address: "[fe80::1]:80", wantErr: true},
+		{address: "[fd00::1]:80", wantErr: true},
+		{address: "[::ffff:127.0.0.1]:80", wantErr: true},
+		{address: "0.0.0.0:80", wantErr: true},
+		{address: "100.64.0.1:80", wantErr: true},
+		{address: "224.0.0.1:80", wantErr: true},
+		{address: "not an address", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			if err := checkPublicAddress(tt.address); (err != nil) != tt.wantErr {
+				t.Errorf("checkPublicAddress(%q) error = %v, wantErr %v", tt.address, err, tt.wantErr)
+			}
+		})
+	}
+}
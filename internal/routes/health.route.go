@@ -0,0 +1,28 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/handlers"
+)
+
+type HealthRoutes struct {
+	healthHandler *handlers.HealthHandler
+}
+
+func NewHealthRoutes(healthHandler *handlers.HealthHandler) *HealthRoutes {
+	return &HealthRoutes{
+		healthHandler: healthHandler,
+	}
+}
+
+func (r *HealthRoutes) Register(router fiber.Router) {
+	health := router.Group("/health")
+
+	health.Get("/live", r.healthHandler.Live)
+	health.Get("/ready", r.healthHandler.Ready)
+}
@@ -0,0 +1,29 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/handlers"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/middlewares"
+)
+
+type InboxRoutes struct {
+	inboxHandler *handlers.InboxHandler
+}
+
+func NewInboxRoutes(inboxHandler *handlers.InboxHandler) *InboxRoutes {
+	return &InboxRoutes{
+		inboxHandler: inboxHandler,
+	}
+}
+
+func (r *InboxRoutes) Register(router fiber.Router) {
+	inbox := router.Group("/inbox/:recipient", middlewares.NewInboxAuth("recipient"))
+
+	inbox.Get("/", r.inboxHandler.ListInbox)
+	inbox.Delete("/:id", r.inboxHandler.DeleteInboxMessage)
+}
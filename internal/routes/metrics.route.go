@@ -0,0 +1,23 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+type MetricsRoutes struct{}
+
+func NewMetricsRoutes() *MetricsRoutes {
+	return &MetricsRoutes{}
+}
+
+// Register serves the Prometheus metrics on /metrics.
+func (r *MetricsRoutes) Register(router fiber.Router) {
+	router.Get("/metrics", adaptor.HTTPHandler(promhttp.Handler()))
+}
@@ -8,6 +8,7 @@ package routes
 import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/ngdangkietswe/go-rabbitmq/internal/handlers"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/middlewares"
 )
 
 type RabbitMQRoutes struct {
@@ -23,7 +24,34 @@ func NewRabbitMQRoutes(rabbitMQHandler *handlers.RabbitMQHandler) *RabbitMQRoute
 func (r *RabbitMQRoutes) Register(router fiber.Router) {
 	rabbitMQ := router.Group("/rabbitmq")
 
-	rabbitMQ.Get("/queues", r.rabbitMQHandler.GetListQueues)
-	rabbitMQ.Get("/exchanges", r.rabbitMQHandler.GetListExchanges)
-	rabbitMQ.Get("/bindings", r.rabbitMQHandler.GetListBindings)
+	admin := middlewares.NewAdminAuth()
+
+	rabbitMQ.Get("/queues", admin, r.rabbitMQHandler.GetListQueues)
+	rabbitMQ.Get("/queues/:name", admin, r.rabbitMQHandler.GetQueue)
+	rabbitMQ.Get("/queues/:name/messages", admin, r.rabbitMQHandler.PeekQueueMessages)
+	rabbitMQ.Post("/queues/:name", admin, r.rabbitMQHandler.CreateQueue)
+	rabbitMQ.Delete("/queues/:name", admin, r.rabbitMQHandler.DeleteQueue)
+	rabbitMQ.Delete("/queues/:name/contents", admin, r.rabbitMQHandler.PurgeQueue)
+	rabbitMQ.Post("/queues/:name/move", admin, r.rabbitMQHandler.MoveQueueMessages)
+
+	rabbitMQ.Get("/jobs/:id", r.rabbitMQHandler.GetMoveJob)
+	rabbitMQ.Delete("/jobs/:id", admin, r.rabbitMQHandler.CancelMoveJob)
+
+	rabbitMQ.Get("/exchanges", admin, r.rabbitMQHandler.GetListExchanges)
+	rabbitMQ.Get("/exchanges/:name", admin, r.rabbitMQHandler.GetExchange)
+	rabbitMQ.Post("/exchanges/:name", admin, r.rabbitMQHandler.CreateExchange)
+	rabbitMQ.Delete("/exchanges/:name", admin, r.rabbitMQHandler.DeleteExchange)
+
+	rabbitMQ.Get("/bindings", admin, r.rabbitMQHandler.GetListBindings)
+	rabbitMQ.Post("/bindings", admin, r.rabbitMQHandler.CreateBinding)
+	rabbitMQ.Get("/bindings/:source/:destinationType/:destination/:propertiesKey", admin, r.rabbitMQHandler.GetBinding)
+	rabbitMQ.Delete("/bindings/:source/:destinationType/:destination/:propertiesKey", admin, r.rabbitMQHandler.DeleteBinding)
+
+	rabbitMQ.Get("/topology/verify", r.rabbitMQHandler.VerifyTopology)
+
+	dlq := rabbitMQ.Group("/dlq")
+	dlq.Get("/", admin, r.rabbitMQHandler.ListDeadLetters)
+	dlq.Delete("/", admin, r.rabbitMQHandler.PurgeDeadLetters)
+	dlq.Post("/replay", admin, r.rabbitMQHandler.ReplayDeadLetters)
+	dlq.Get("/:id", admin, r.rabbitMQHandler.GetDeadLetter)
 }
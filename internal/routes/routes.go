@@ -13,17 +13,31 @@ import (
 
 type AppRoutes struct {
 	notificationHandler *handlers.NotificationHandler
+	inboxHandler        *handlers.InboxHandler
+	templateHandler     *handlers.TemplateHandler
 	rabbitMQHandler     *handlers.RabbitMQHandler
+	healthHandler       *handlers.HealthHandler
 }
 
-func NewAppRoutes(notificationHandler *handlers.NotificationHandler, rabbitMQHandler *handlers.RabbitMQHandler) *AppRoutes {
+func NewAppRoutes(notificationHandler *handlers.NotificationHandler, inboxHandler *handlers.InboxHandler, templateHandler *handlers.TemplateHandler, rabbitMQHandler *handlers.RabbitMQHandler, healthHandler *handlers.HealthHandler) *AppRoutes {
 	return &AppRoutes{
 		notificationHandler: notificationHandler,
+		inboxHandler:        inboxHandler,
+		templateHandler:     templateHandler,
 		rabbitMQHandler:     rabbitMQHandler,
+		healthHandler:       healthHandler,
 	}
 }
 
 func (r *AppRoutes) Register(app *fiber.App) {
+	// Liveness and readiness probes, outside of the versioned API
+	healthRoutes := NewHealthRoutes(r.healthHandler)
+	healthRoutes.Register(app)
+
+	// Prometheus metrics
+	metricsRoutes := NewMetricsRoutes()
+	metricsRoutes.Register(app)
+
 	api := app.Group("/api/v1")
 
 	// Heath check
@@ -33,6 +47,14 @@ func (r *AppRoutes) Register(app *fiber.App) {
 	notificationRoutes := NewNotificationRoutes(r.notificationHandler)
 	notificationRoutes.Register(api)
 
+	// In-app inbox routes
+	inboxRoutes := NewInboxRoutes(r.inboxHandler)
+	inboxRoutes.Register(api)
+
+	// Message template routes
+	templateRoutes := NewTemplateRoutes(r.templateHandler)
+	templateRoutes.Register(api)
+
 	// RabbitMQ routes
 	rabbitMQRoutes := NewRabbitMQRoutes(r.rabbitMQHandler)
 	rabbitMQRoutes.Register(api)
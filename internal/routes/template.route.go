@@ -0,0 +1,29 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/handlers"
+)
+
+type TemplateRoutes struct {
+	templateHandler *handlers.TemplateHandler
+}
+
+func NewTemplateRoutes(templateHandler *handlers.TemplateHandler) *TemplateRoutes {
+	return &TemplateRoutes{
+		templateHandler: templateHandler,
+	}
+}
+
+func (r *TemplateRoutes) Register(router fiber.Router) {
+	templates := router.Group("/templates")
+
+	templates.Get("/", r.templateHandler.ListTemplates)
+	templates.Get("/:id", r.templateHandler.GetTemplate)
+	templates.Post("/:id/preview", r.templateHandler.PreviewTemplate)
+}
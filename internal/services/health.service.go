@@ -0,0 +1,106 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package services
+
+import (
+	"context"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/config"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+)
+
+// HealthCheck reports why a dependency is unusable, or nil when it is usable.
+type HealthCheck func(ctx context.Context) error
+
+type HealthService struct {
+	mu      sync.RWMutex
+	checks  map[string]HealthCheck
+	timeout time.Duration
+	logger  *zap.Logger
+}
+
+func NewHealthService(logger *zap.Logger) *HealthService {
+	return &HealthService{
+		checks:  make(map[string]HealthCheck),
+		timeout: time.Duration(config.GetInt("HEALTH_CHECK_TIMEOUT_MS", 2000)) * time.Millisecond,
+		logger:  logger,
+	}
+}
+
+// Register adds a check that Ready runs, replacing any check registered under the same name.
+func (h *HealthService) Register(name string, check HealthCheck) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.checks[name] = check
+}
+
+// Live reports that the process is up. It deliberately checks no dependency, so that an outage of the
+// broker makes pods unready instead of getting them restarted.
+func (h *HealthService) Live() *models.HealthReport {
+	return &models.HealthReport{
+		Status:    models.HealthStatusUp,
+		Timestamp: time.Now().UTC(),
+	}
+}
+
+// Ready runs every registered check concurrently, each bounded by the health check timeout, and reports
+// up only if all of them passed.
+func (h *HealthService) Ready(ctx context.Context) *models.HealthReport {
+	h.mu.RLock()
+	checks := make(map[string]HealthCheck, len(h.checks))
+	for name, check := range h.checks {
+		checks[name] = check
+	}
+	h.mu.RUnlock()
+
+	report := &models.HealthReport{
+		Status:    models.HealthStatusUp,
+		Checks:    make(map[string]models.HealthCheckResult, len(checks)),
+		Timestamp: time.Now().UTC(),
+	}
+
+	var (
+		mu sync.Mutex
+		wg sync.WaitGroup
+	)
+
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check HealthCheck) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, h.timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := check(checkCtx)
+
+			result := models.HealthCheckResult{
+				Status:     models.HealthStatusUp,
+				DurationMs: time.Since(start).Milliseconds(),
+			}
+			if err != nil {
+				result.Status = models.HealthStatusDown
+				result.Error = err.Error()
+				h.logger.Warn("Health check failed", zap.String("check", name), zap.Error(err))
+			}
+
+			mu.Lock()
+			report.Checks[name] = result
+			if err != nil {
+				report.Status = models.HealthStatusDown
+			}
+			mu.Unlock()
+		}(name, check)
+	}
+
+	wg.Wait()
+
+	return report
+}
@@ -6,46 +6,67 @@
 package services
 
 import (
+	"context"
 	"fmt"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/metrics"
 	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/tracing"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/logger"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	"time"
 )
 
+// Provider delivers notifications of one channel, e.g. email over SMTP. Send returns an error wrapped with
+// Permanent when the notification can never be delivered as it is, e.g. an invalid recipient, so that it is
+// dead-lettered instead of retried.
+type Provider interface {
+	// Name identifies the provider in logs and spans, e.g. "smtp".
+	Name() string
+	Send(ctx context.Context, notification *models.Notification) error
+}
+
 type NotificationService struct {
-	logger *zap.Logger
+	logger    *zap.Logger
+	providers map[models.NotificationType]Provider
 }
 
 func NewNotificationService(logger *zap.Logger) *NotificationService {
 	return &NotificationService{
-		logger: logger,
+		logger:    logger,
+		providers: make(map[models.NotificationType]Provider),
 	}
 }
 
-func (ns *NotificationService) ProcessNotification(notification *models.Notification) error {
+// Register makes provider deliver the notifications of channel, replacing the provider registered before.
+func (ns *NotificationService) Register(channel models.NotificationType, provider Provider) {
+	ns.providers[channel] = provider
+}
+
+func (ns *NotificationService) ProcessNotification(ctx context.Context, notification *models.Notification) (err error) {
+	start := time.Now()
+	defer func() {
+		metrics.ProcessingDuration.WithLabelValues(ns.typeLabel(notification.Type), metrics.Result(err)).Observe(time.Since(start).Seconds())
+	}()
+
+	log := logger.FromContext(ctx, ns.logger)
+
 	notification.Status = models.NotificationStatusProcessing
 
-	ns.logger.Info("Processing notification", zap.String("id", notification.ID), zap.String("type", string(notification.Type)), zap.String("recipient", notification.Recipient))
-
-	switch notification.Type {
-	case models.NotificationTypeEmail:
-		if err := ns.sendEmail(notification); err != nil {
-			notification.Status = models.NotificationStatusFailed
-			notification.Error = err.Error()
-			return fmt.Errorf("failed to send email notification %s: %w", notification.ID, err)
-		}
-	case models.NotificationTypeSMS:
-		if err := ns.sendSMS(notification); err != nil {
-			notification.Status = models.NotificationStatusFailed
-			notification.Error = err.Error()
-			return fmt.Errorf("failed to send SMS notification %s: %w", notification.ID, err)
-		}
-	case models.NotificationTypePush:
-		if err := ns.sendPush(notification); err != nil {
-			notification.Status = models.NotificationStatusFailed
-			notification.Error = err.Error()
-			return fmt.Errorf("failed to send push notification %s: %w", notification.ID, err)
-		}
+	log.Info("Processing notification", zap.String("id", notification.ID), zap.String("type", string(notification.Type)), zap.String("recipient", notification.Recipient))
+
+	provider, ok := ns.providers[notification.Type]
+	if !ok {
+		notification.Status = models.NotificationStatusFailed
+		notification.Error = fmt.Sprintf("no provider for notification type %q", notification.Type)
+		return Permanent(fmt.Errorf("failed to send notification %s: no provider for notification type %q", notification.ID, notification.Type))
+	}
+
+	if err := ns.send(ctx, provider, notification); err != nil {
+		notification.Status = models.NotificationStatusFailed
+		notification.Error = err.Error()
+		return fmt.Errorf("failed to send %s notification %s: %w", notification.Type, notification.ID, err)
 	}
 
 	now := time.Now()
@@ -53,25 +74,39 @@ func (ns *NotificationService) ProcessNotification(notification *models.Notifica
 	notification.Status = models.NotificationStatusSent
 	notification.SentAt = &now
 
-	ns.logger.Info("Notification sent successfully", zap.String("id", notification.ID), zap.String("type", string(notification.Type)), zap.String("recipient", notification.Recipient))
+	log.Info("Notification sent successfully", zap.String("id", notification.ID), zap.String("type", string(notification.Type)), zap.String("provider", provider.Name()), zap.String("recipient", notification.Recipient))
 
 	return nil
 }
 
-func (ns *NotificationService) sendEmail(notification *models.Notification) error {
-	// Implement email sending logic here
-	ns.logger.Info("Sending email", zap.String("recipient", notification.Recipient), zap.String("message", notification.Message))
-	return nil
+func (ns *NotificationService) send(ctx context.Context, provider Provider, notification *models.Notification) (err error) {
+	ctx, span := startProviderSpan(ctx, provider.Name(), notification)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	return provider.Send(ctx, notification)
 }
 
-func (ns *NotificationService) sendSMS(notification *models.Notification) error {
-	// Implement SMS sending logic here
-	ns.logger.Info("Sending SMS", zap.String("recipient", notification.Recipient), zap.String("message", notification.Message))
-	return nil
+// startProviderSpan starts the client span of a call to the provider delivering notification. The recipient
+// is left out, spans are exported outside of the service.
+func startProviderSpan(ctx context.Context, provider string, notification *models.Notification) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, fmt.Sprintf("send %s", notification.Type),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("notification.id", notification.ID),
+			attribute.String("notification.type", string(notification.Type)),
+			attribute.String("notification.provider", provider),
+		),
+	)
 }
 
-func (ns *NotificationService) sendPush(notification *models.Notification) error {
-	// Implement push notification sending logic here
-	ns.logger.Info("Sending push", zap.String("recipient", notification.Recipient), zap.String("message", notification.Message))
-	return nil
+// typeLabel bounds the values of the type label to the registered channels, the type of a consumed
+// notification is not validated.
+func (ns *NotificationService) typeLabel(notificationType models.NotificationType) string {
+	if _, ok := ns.providers[notificationType]; ok {
+		return string(notificationType)
+	}
+	return "unknown"
 }
@@ -0,0 +1,296 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/management"
+	"github.com/samber/lo"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrAlreadyExists is returned when creating a queue or exchange that already exists.
+	ErrAlreadyExists = errors.New("rabbitmq: already exists")
+	// ErrInvalidDefinition is returned when a queue, exchange or binding to create is invalid.
+	ErrInvalidDefinition = errors.New("rabbitmq: invalid definition")
+	// ErrManagedByTopology is returned when deleting an object the service declares from its topology file,
+	// which the service depends on and would declare again on the next reconnect anyway.
+	ErrManagedByTopology = errors.New("rabbitmq: managed by the topology file")
+)
+
+// GetQueue returns a queue with its consumers and bindings.
+func (r *RabbitMQService) GetQueue(ctx context.Context, name string) (*models.QueueDetails, error) {
+	queue, err := r.management.GetQueue(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("get queue %s: %w", name, err)
+	}
+
+	bindings, err := r.management.ListQueueBindings(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("get bindings of queue %s: %w", name, err)
+	}
+
+	return toQueueDetails(queue, bindings), nil
+}
+
+func (r *RabbitMQService) CreateQueue(ctx context.Context, name string, request *models.CreateQueueRequest, actor string) (*models.QueueDetails, error) {
+	arguments := lo.Assign(request.Arguments)
+	if request.Type != "" {
+		if !queueTypes[request.Type] {
+			return nil, fmt.Errorf("%w: unknown queue type %q", ErrInvalidDefinition, request.Type)
+		}
+		arguments["x-queue-type"] = request.Type
+	}
+
+	if err := r.ensureAbsent(ctx, "queue", name, func() error {
+		_, err := r.management.GetQueue(ctx, name)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := r.management.DeclareQueue(ctx, name, management.QueueSettings{
+		Durable:    lo.FromPtrOr(request.Durable, true),
+		AutoDelete: request.AutoDelete,
+		Arguments:  arguments,
+	}); err != nil {
+		return nil, fmt.Errorf("declare queue %s: %w", name, err)
+	}
+
+	r.logger.Info("Audit: queue created", zap.String("queue", name), zap.String("created_by", actor))
+
+	return r.GetQueue(ctx, name)
+}
+
+// DeleteQueue deletes a queue with its messages. With ifEmpty or ifUnused the broker refuses to delete a
+// queue that still has messages or consumers.
+func (r *RabbitMQService) DeleteQueue(ctx context.Context, name string, ifEmpty, ifUnused bool, actor string) error {
+	if lo.ContainsBy(r.topology.Queues, func(queue *models.QueueConfig) bool { return queue.Name == name }) {
+		return fmt.Errorf("%w: queue %s", ErrManagedByTopology, name)
+	}
+
+	if err := r.management.DeleteQueue(ctx, name, ifEmpty, ifUnused); err != nil {
+		return fmt.Errorf("delete queue %s: %w", name, err)
+	}
+
+	r.logger.Info("Audit: queue deleted", zap.String("queue", name), zap.Bool("if_empty", ifEmpty), zap.Bool("if_unused", ifUnused), zap.String("deleted_by", actor))
+
+	return nil
+}
+
+// PurgeQueue drops the ready messages of a queue and returns how many were dropped. Unacknowledged
+// messages are not affected.
+func (r *RabbitMQService) PurgeQueue(ctx context.Context, name, actor string) (int, error) {
+	// the management API reports a missing queue as such, where purging it would only close the channel
+	if _, err := r.management.GetQueue(ctx, name); err != nil {
+		return 0, fmt.Errorf("get queue %s: %w", name, err)
+	}
+
+	ch, err := r.openChannel(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = ch.Close()
+	}()
+
+	purged, err := ch.QueuePurge(name, false)
+	if err != nil {
+		return 0, fmt.Errorf("purge queue %s: %w", name, err)
+	}
+
+	r.logger.Info("Audit: queue purged", zap.String("queue", name), zap.Int("purged", purged), zap.String("purged_by", actor))
+
+	return purged, nil
+}
+
+// GetExchange returns an exchange with the bindings it is the source of.
+func (r *RabbitMQService) GetExchange(ctx context.Context, name string) (*models.Exchange, error) {
+	data, err := r.management.GetExchange(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("get exchange %s: %w", name, err)
+	}
+
+	bindings, err := r.management.ListExchangeBindings(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("get bindings of exchange %s: %w", name, err)
+	}
+
+	exchange := toExchange(data)
+	for i := range bindings {
+		exchange.Bindings = append(exchange.Bindings, *toBinding(&bindings[i]))
+	}
+
+	return exchange, nil
+}
+
+func (r *RabbitMQService) CreateExchange(ctx context.Context, name string, request *models.CreateExchangeRequest, actor string) (*models.Exchange, error) {
+	if !exchangeTypes[request.Type] {
+		return nil, fmt.Errorf("%w: unknown exchange type %q", ErrInvalidDefinition, request.Type)
+	}
+
+	if err := r.ensureAbsent(ctx, "exchange", name, func() error {
+		_, err := r.management.GetExchange(ctx, name)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if err := r.management.DeclareExchange(ctx, name, management.ExchangeSettings{
+		Type:       request.Type,
+		Durable:    lo.FromPtrOr(request.Durable, true),
+		AutoDelete: request.AutoDelete,
+		Internal:   request.Internal,
+		Arguments:  request.Arguments,
+	}); err != nil {
+		return nil, fmt.Errorf("declare exchange %s: %w", name, err)
+	}
+
+	r.logger.Info("Audit: exchange created", zap.String("exchange", name), zap.String("type", request.Type), zap.String("created_by", actor))
+
+	return r.GetExchange(ctx, name)
+}
+
+// DeleteExchange deletes an exchange and its bindings. With ifUnused the broker refuses to delete an
+// exchange that is the source of a binding.
+func (r *RabbitMQService) DeleteExchange(ctx context.Context, name string, ifUnused bool, actor string) error {
+	if builtinExchanges[name] || lo.ContainsBy(r.topology.Exchanges, func(exchange *models.ExchangeConfig) bool { return exchange.Name == name }) {
+		return fmt.Errorf("%w: exchange %s", ErrManagedByTopology, name)
+	}
+
+	if err := r.management.DeleteExchange(ctx, name, ifUnused); err != nil {
+		return fmt.Errorf("delete exchange %s: %w", name, err)
+	}
+
+	r.logger.Info("Audit: exchange deleted", zap.String("exchange", name), zap.Bool("if_unused", ifUnused), zap.String("deleted_by", actor))
+
+	return nil
+}
+
+func (r *RabbitMQService) GetBinding(ctx context.Context, source, destinationType, destination, propertiesKey string) (*models.Binding, error) {
+	toExchange, err := bindingToExchange(destinationType)
+	if err != nil {
+		return nil, err
+	}
+
+	binding, err := r.management.GetBinding(ctx, source, destination, toExchange, propertiesKey)
+	if err != nil {
+		return nil, fmt.Errorf("get binding %s -> %s: %w", source, destination, err)
+	}
+
+	return toBinding(binding), nil
+}
+
+func (r *RabbitMQService) CreateBinding(ctx context.Context, request *models.CreateBindingRequest, actor string) (*models.Binding, error) {
+	toExchange, err := bindingToExchange(request.DestinationType)
+	if err != nil {
+		return nil, err
+	}
+	if request.Source == "" {
+		return nil, fmt.Errorf("%w: the default exchange can't be bound explicitly", ErrInvalidDefinition)
+	}
+
+	propertiesKey, err := r.management.CreateBinding(ctx, request.Source, request.Destination, toExchange, management.BindingSettings{
+		RoutingKey: request.RoutingKey,
+		Arguments:  request.Arguments,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create binding %s -> %s: %w", request.Source, request.Destination, err)
+	}
+
+	r.logger.Info("Audit: binding created", zap.String("source", request.Source), zap.String("destination", request.Destination), zap.String("destination_type", request.DestinationType), zap.String("routing_key", request.RoutingKey), zap.String("created_by", actor))
+
+	return r.GetBinding(ctx, request.Source, request.DestinationType, request.Destination, propertiesKey)
+}
+
+func (r *RabbitMQService) DeleteBinding(ctx context.Context, source, destinationType, destination, propertiesKey, actor string) error {
+	toExchange, err := bindingToExchange(destinationType)
+	if err != nil {
+		return err
+	}
+
+	binding, err := r.management.GetBinding(ctx, source, destination, toExchange, propertiesKey)
+	if err != nil {
+		return fmt.Errorf("get binding %s -> %s: %w", source, destination, err)
+	}
+
+	if lo.ContainsBy(r.topology.Bindings, func(b *models.BindingConfig) bool {
+		return b.Exchange == source && b.RoutingKey == binding.RoutingKey &&
+			(toExchange && b.DestinationExchange == destination || !toExchange && b.Queue == destination)
+	}) {
+		return fmt.Errorf("%w: binding %s -> %s (%s)", ErrManagedByTopology, source, destination, binding.RoutingKey)
+	}
+
+	if err := r.management.DeleteBinding(ctx, source, destination, toExchange, propertiesKey); err != nil {
+		return fmt.Errorf("delete binding %s -> %s: %w", source, destination, err)
+	}
+
+	r.logger.Info("Audit: binding deleted", zap.String("source", source), zap.String("destination", destination), zap.String("destination_type", destinationType), zap.String("routing_key", binding.RoutingKey), zap.String("deleted_by", actor))
+
+	return nil
+}
+
+// ensureAbsent returns ErrAlreadyExists unless get reports the object as not found. Declaring through the
+// management API is idempotent, so without this check creating an existing object would silently succeed.
+func (r *RabbitMQService) ensureAbsent(ctx context.Context, kind, name string, get func() error) error {
+	err := get()
+	switch {
+	case err == nil:
+		return fmt.Errorf("%w: %s %s", ErrAlreadyExists, kind, name)
+	case errors.Is(err, management.ErrNotFound):
+		return nil
+	default:
+		return fmt.Errorf("get %s %s: %w", kind, name, err)
+	}
+}
+
+func bindingToExchange(destinationType string) (bool, error) {
+	switch destinationType {
+	case "queue":
+		return false, nil
+	case "exchange":
+		return true, nil
+	default:
+		return false, fmt.Errorf("%w: destination type must be queue or exchange, got %q", ErrInvalidDefinition, destinationType)
+	}
+}
+
+func toQueueDetails(q *management.Queue, bindings []management.Binding) *models.QueueDetails {
+	details := &models.QueueDetails{
+		Queue:                      *toQueue(q),
+		Node:                       q.Node,
+		Memory:                     q.Memory,
+		MessageBytes:               q.MessageBytes,
+		MessageBytesReady:          q.MessageBytesReady,
+		MessageBytesUnacknowledged: q.MessageBytesUnacknowledged,
+		ConsumerUtilisation:        q.ConsumerUtilisation,
+		IdleSince:                  q.IdleSince,
+		ConsumerDetails:            make([]models.Consumer, 0, len(q.ConsumerDetails)),
+		Bindings:                   make([]models.Binding, 0, len(bindings)),
+	}
+
+	for _, c := range q.ConsumerDetails {
+		details.ConsumerDetails = append(details.ConsumerDetails, models.Consumer{
+			ConsumerTag:   c.ConsumerTag,
+			AckRequired:   c.AckRequired,
+			PrefetchCount: c.PrefetchCount,
+			Active:        c.Active,
+			Channel:       c.ChannelDetails.Name,
+			Connection:    c.ChannelDetails.ConnectionName,
+			PeerHost:      c.ChannelDetails.PeerHost,
+		})
+	}
+
+	for i := range bindings {
+		details.Bindings = append(details.Bindings, *toBinding(&bindings[i]))
+	}
+
+	return details
+}
@@ -0,0 +1,310 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/metrics"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/zap"
+	"math/rand"
+	"net/url"
+	"time"
+)
+
+var (
+	// ErrNotConnected is returned when the broker connection is down and the caller asked to fail fast,
+	// or when the context expired before the connection was recovered.
+	ErrNotConnected = errors.New("rabbitmq: not connected")
+	// ErrServiceClosed is returned once Close has been called on the service.
+	ErrServiceClosed = errors.New("rabbitmq: service closed")
+)
+
+type backoff struct {
+	minDelay time.Duration
+	maxDelay time.Duration
+	attempt  int
+}
+
+func newBackoff(minDelay, maxDelay time.Duration) *backoff {
+	return &backoff{minDelay: minDelay, maxDelay: maxDelay}
+}
+
+// next returns the delay before the following attempt: exponential growth capped at maxDelay, jittered
+// over the upper half so that many pods restarting together don't reconnect in lockstep.
+func (b *backoff) next() time.Duration {
+	d := b.minDelay << b.attempt
+	if d <= 0 || d > b.maxDelay {
+		d = b.maxDelay
+	} else {
+		b.attempt++
+	}
+
+	half := int64(d / 2)
+	return time.Duration(half + rand.Int63n(half+1))
+}
+
+func (b *backoff) reset() {
+	b.attempt = 0
+}
+
+// RedactURL hides the password of a connection URL so that it can be logged.
+func RedactURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return "<unparseable url>"
+	}
+
+	return u.Redacted()
+}
+
+// dialWithRetry dials the broker at startup, retrying with backoff until startupTimeout has elapsed or ctx
+// is done, so that the service can start before the broker does without delaying a start when it is up.
+func (r *RabbitMQService) dialWithRetry(ctx context.Context) (*amqp.Connection, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.startupTimeout)
+	defer cancel()
+
+	bo := newBackoff(r.reconnectMinBackoff, r.reconnectMaxBackoff)
+	redacted := RedactURL(r.url)
+
+	for attempt := 1; ; attempt++ {
+		conn, err := amqp.Dial(r.url)
+		if err == nil {
+			r.logger.Info("Connected to RabbitMQ", zap.String("url", redacted), zap.Int("attempt", attempt))
+			return conn, nil
+		}
+
+		delay := bo.next()
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			delay = time.Until(deadline)
+		}
+
+		r.logger.Warn("Failed to connect to RabbitMQ", zap.String("url", redacted), zap.Int("attempt", attempt), zap.Duration("retry_in", delay), zap.Error(err))
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("connect to %s: giving up after %d attempts within %s: %w", redacted, attempt, r.startupTimeout, err)
+		case <-time.After(delay):
+		}
+	}
+}
+
+// connect dials the broker and opens the service channels on the new connection.
+func (r *RabbitMQService) connect() error {
+	conn, err := amqp.Dial(r.url)
+	if err != nil {
+		return fmt.Errorf("dial: %w", err)
+	}
+
+	if err := r.openChannels(conn); err != nil {
+		_ = conn.Close()
+		return err
+	}
+
+	return nil
+}
+
+// openChannels declares the topology, unless it is only verified, and opens the service channel and the
+// confirm-mode publish channel on conn. It is also used on its own when the broker closed a channel but
+// kept the connection.
+func (r *RabbitMQService) openChannels(conn *amqp.Connection) error {
+	if r.topologyMode == TopologyModeDeclare {
+		if err := r.declareTopology(conn); err != nil {
+			return err
+		}
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("open channel: %w", err)
+	}
+
+	pubCh, err := conn.Channel()
+	if err != nil {
+		_ = ch.Close()
+		return fmt.Errorf("open publish channel: %w", err)
+	}
+
+	if err := pubCh.Confirm(false); err != nil {
+		_ = ch.Close()
+		_ = pubCh.Close()
+		return fmt.Errorf("enable publisher confirms: %w", err)
+	}
+
+	r.mu.Lock()
+	r.conn = conn
+	r.ch = ch
+	r.pubCh = newConfirmChannel(pubCh, r.logger)
+	close(r.ready)
+	r.mu.Unlock()
+
+	return nil
+}
+
+// supervise watches the connection and the service channels and recovers whichever of them goes away.
+// It runs until Close is called.
+func (r *RabbitMQService) supervise() {
+	bo := newBackoff(r.reconnectMinBackoff, r.reconnectMaxBackoff)
+
+	for {
+		r.mu.RLock()
+		conn, ch, pubCh := r.conn, r.ch, r.pubCh
+		r.mu.RUnlock()
+
+		connClosed := conn.NotifyClose(make(chan *amqp.Error, 1))
+		chClosed := ch.NotifyClose(make(chan *amqp.Error, 1))
+		pubChClosed := pubCh.NotifyClose(make(chan *amqp.Error, 1))
+
+		select {
+		case <-r.done:
+			return
+		case amqpErr := <-connClosed:
+			r.logger.Warn("RabbitMQ connection closed", zap.Any("reason", amqpErr))
+		case amqpErr := <-chClosed:
+			r.logger.Warn("RabbitMQ channel closed", zap.Any("reason", amqpErr))
+		case amqpErr := <-pubChClosed:
+			r.logger.Warn("RabbitMQ publish channel closed", zap.Any("reason", amqpErr))
+		}
+
+		select {
+		case <-r.done:
+			return
+		default:
+		}
+
+		r.mu.Lock()
+		r.ready = make(chan struct{})
+		r.mu.Unlock()
+
+		// recover both channels together, whichever one is still open is not worth keeping around
+		_ = ch.Close()
+		_ = pubCh.Close()
+
+		for attempt := 1; ; attempt++ {
+			var err error
+			if conn.IsClosed() {
+				err = r.connect()
+			} else {
+				err = r.openChannels(conn)
+			}
+
+			metrics.Reconnects.WithLabelValues(metrics.Result(err)).Inc()
+
+			if err == nil {
+				r.logger.Info("RabbitMQ connection recovered", zap.Int("attempt", attempt))
+				bo.reset()
+				break
+			}
+
+			delay := bo.next()
+			r.logger.Error("Failed to recover RabbitMQ connection", zap.Int("attempt", attempt), zap.Duration("retry_in", delay), zap.Error(err))
+
+			select {
+			case <-r.done:
+				return
+			case <-time.After(delay):
+			}
+		}
+	}
+}
+
+// channel returns the current service channel. When the connection is down it either fails fast with
+// ErrNotConnected or waits for the supervisor to recover it until ctx is done.
+func (r *RabbitMQService) channel(ctx context.Context, failFast bool) (*amqp.Channel, error) {
+	return await(ctx, r, failFast, func() *amqp.Channel { return r.ch })
+}
+
+// publishChannel is like channel but returns the confirm-mode publish channel.
+func (r *RabbitMQService) publishChannel(ctx context.Context, failFast bool) (*confirmChannel, error) {
+	return await(ctx, r, failFast, func() *confirmChannel { return r.pubCh })
+}
+
+// openChannel opens a new channel owned by the caller, waiting for the connection until ctx is done.
+func (r *RabbitMQService) openChannel(ctx context.Context) (*amqp.Channel, error) {
+	conn, err := await(ctx, r, false, func() *amqp.Connection { return r.conn })
+	if err != nil {
+		return nil, err
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("open channel: %w", err)
+	}
+
+	return ch, nil
+}
+
+func await[T interface{ IsClosed() bool }](ctx context.Context, r *RabbitMQService, failFast bool, pick func() T) (T, error) {
+	var zero T
+
+	for {
+		r.mu.RLock()
+		res, ready := pick(), r.ready
+		r.mu.RUnlock()
+
+		select {
+		case <-r.done:
+			return zero, ErrServiceClosed
+		default:
+		}
+
+		select {
+		case <-ready:
+			if !res.IsClosed() {
+				return res, nil
+			}
+			// closed but the supervisor hasn't noticed yet, poll instead of spinning on ready
+			ready = nil
+		default:
+		}
+
+		if failFast {
+			return zero, ErrNotConnected
+		}
+
+		select {
+		case <-r.done:
+			return zero, ErrServiceClosed
+		case <-ctx.Done():
+			return zero, fmt.Errorf("%w: %w", ErrNotConnected, ctx.Err())
+		case <-ready:
+		case <-time.After(r.reconnectMinBackoff):
+		}
+	}
+}
+
+// CheckConnection reports whether the connection and the service channels are currently usable, without
+// waiting for a recovery in progress.
+func (r *RabbitMQService) CheckConnection(_ context.Context) error {
+	r.mu.RLock()
+	conn, ch, pubCh, ready := r.conn, r.ch, r.pubCh, r.ready
+	r.mu.RUnlock()
+
+	select {
+	case <-r.done:
+		return ErrServiceClosed
+	default:
+	}
+
+	select {
+	case <-ready:
+	default:
+		return fmt.Errorf("%w: recovering the connection", ErrNotConnected)
+	}
+
+	switch {
+	case conn.IsClosed():
+		return fmt.Errorf("%w: connection closed", ErrNotConnected)
+	case ch.IsClosed():
+		return fmt.Errorf("%w: channel closed", ErrNotConnected)
+	case pubCh.IsClosed():
+		return fmt.Errorf("%w: publish channel closed", ErrNotConnected)
+	}
+
+	return nil
+}
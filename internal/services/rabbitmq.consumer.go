@@ -0,0 +1,429 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/metrics"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/tracing"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/logger"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// MessageHandler processes one delivery. ctx carries the span of the delivery, continuing the trace of the
+// publisher when the message headers carry one. Handlers must not acknowledge the delivery themselves, the
+// consumer settles it from the returned error:
+//   - nil acks the message.
+//   - an error wrapped with Permanent rejects it without requeue, so it is dead-lettered.
+//   - an error wrapped with Retryable, or any other error, is retried: through the delay queues of the
+//     queue's RetryPolicy when it has one, otherwise by requeueing it once and rejecting it when it fails
+//     again as a redelivery.
+//
+// A panic in the handler is recovered and treated as a permanent failure, since the same message would
+// most likely crash the handler again.
+type MessageHandler func(ctx context.Context, delivery amqp.Delivery) error
+
+const retryPublishTimeout = 30 * time.Second
+
+// PermanentError marks a handler failure that will not succeed on another attempt, e.g. a malformed payload.
+type PermanentError struct {
+	Err error
+}
+
+func (e *PermanentError) Error() string {
+	return fmt.Sprintf("permanent: %v", e.Err)
+}
+
+func (e *PermanentError) Unwrap() error {
+	return e.Err
+}
+
+// RetryableError marks a handler failure that may succeed on another attempt, e.g. a provider timeout.
+type RetryableError struct {
+	Err error
+}
+
+func (e *RetryableError) Error() string {
+	return fmt.Sprintf("retryable: %v", e.Err)
+}
+
+func (e *RetryableError) Unwrap() error {
+	return e.Err
+}
+
+func Permanent(err error) error {
+	return &PermanentError{Err: err}
+}
+
+func Retryable(err error) error {
+	return &RetryableError{Err: err}
+}
+
+func IsPermanent(err error) bool {
+	var permanent *PermanentError
+	return errors.As(err, &permanent)
+}
+
+type ConsumeOption func(*consumeOptions)
+
+type consumeOptions struct {
+	concurrency int
+	prefetch    int
+}
+
+// WithConcurrency sets the number of goroutines handling deliveries of the consumer. Defaults to 1.
+func WithConcurrency(concurrency int) ConsumeOption {
+	return func(o *consumeOptions) {
+		o.concurrency = concurrency
+	}
+}
+
+// WithPrefetch sets the QoS prefetch count of the consumer channel, i.e. the upper bound on unacked
+// deliveries the broker pushes to it. Defaults to the concurrency.
+func WithPrefetch(prefetch int) ConsumeOption {
+	return func(o *consumeOptions) {
+		o.prefetch = prefetch
+	}
+}
+
+// consumeChannel is the part of *amqp.Channel a consumer uses, so that tests can stand in for the broker.
+type consumeChannel interface {
+	Qos(prefetchCount, prefetchSize int, global bool) error
+	Consume(queue, consumer string, autoAck, exclusive, noLocal, noWait bool, args amqp.Table) (<-chan amqp.Delivery, error)
+	Cancel(consumer string, noWait bool) error
+	Close() error
+}
+
+// Consumer is a handle on a queue subscription started by RabbitMQService.Consume.
+type Consumer struct {
+	rabbitMQ    *RabbitMQService
+	open        func(ctx context.Context) (consumeChannel, error) // opens the channel of each subscription
+	queue       string
+	tag         string
+	handler     MessageHandler
+	retry       *RetryPolicy
+	concurrency int
+	prefetch    int
+	logger      *zap.Logger
+	cancel      context.CancelFunc
+	done        chan struct{}
+	registered  atomic.Bool // whether the subscription is currently active on the broker
+}
+
+// Consume subscribes handler to queue on a channel of its own and returns once the subscription is
+// registered. Deliveries are handled until ctx is done or Stop is called; on shutdown the consumer tag is
+// cancelled, deliveries already received are drained through handler and only then the channel is closed.
+// If the channel or connection is lost the subscription is registered again once it has been recovered.
+func (r *RabbitMQService) Consume(ctx context.Context, queue string, handler MessageHandler, opts ...ConsumeOption) (*Consumer, error) {
+	options := &consumeOptions{concurrency: 1}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	if options.concurrency < 1 {
+		return nil, fmt.Errorf("consume queue %s: concurrency must be at least 1, got %d", queue, options.concurrency)
+	}
+	if options.prefetch <= 0 {
+		options.prefetch = options.concurrency
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	c := &Consumer{
+		rabbitMQ:    r,
+		open:        r.openConsumeChannel,
+		queue:       queue,
+		tag:         fmt.Sprintf("%s-%s", queue, uuid.New().String()),
+		handler:     handler,
+		retry:       r.retryPolicies[queue],
+		concurrency: options.concurrency,
+		prefetch:    options.prefetch,
+		logger:      r.logger.With(zap.String("queue", queue)),
+		cancel:      cancel,
+		done:        make(chan struct{}),
+	}
+
+	if err := c.start(ctx); err != nil {
+		cancel()
+		return nil, fmt.Errorf("consume queue %s: %w", queue, err)
+	}
+
+	return c, nil
+}
+
+func (r *RabbitMQService) openConsumeChannel(ctx context.Context) (consumeChannel, error) {
+	ch, err := r.openChannel(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
+}
+
+// start registers the subscription and handles its deliveries in the background until ctx is done.
+func (c *Consumer) start(ctx context.Context) error {
+	ch, msgs, err := c.register(ctx)
+	if err != nil {
+		return err
+	}
+
+	c.logger.Info("Consumer registered", zap.String("consumer_tag", c.tag), zap.Int("concurrency", c.concurrency), zap.Int("prefetch", c.prefetch))
+
+	go c.run(ctx, ch, msgs)
+
+	return nil
+}
+
+// Stop cancels the subscription and blocks until in-flight deliveries have been handled.
+func (c *Consumer) Stop() {
+	c.cancel()
+	<-c.done
+}
+
+// Wait blocks until the consumer has stopped, either through Stop or because its context was done.
+func (c *Consumer) Wait() {
+	<-c.done
+}
+
+// Check reports whether the consumer is currently receiving deliveries, for readiness probes.
+func (c *Consumer) Check(_ context.Context) error {
+	select {
+	case <-c.done:
+		return fmt.Errorf("consumer of queue %s stopped", c.queue)
+	default:
+	}
+
+	if !c.registered.Load() {
+		return fmt.Errorf("consumer of queue %s is not registered, waiting for the channel to recover", c.queue)
+	}
+
+	return nil
+}
+
+// Done is closed once the consumer has stopped.
+func (c *Consumer) Done() <-chan struct{} {
+	return c.done
+}
+
+func (c *Consumer) register(ctx context.Context) (consumeChannel, <-chan amqp.Delivery, error) {
+	ch, err := c.open(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := ch.Qos(c.prefetch, 0, false); err != nil {
+		_ = ch.Close()
+		return nil, nil, fmt.Errorf("set qos: %w", err)
+	}
+
+	msgs, err := ch.Consume(
+		c.queue, c.tag, false, false, false, false, nil,
+	)
+	if err != nil {
+		_ = ch.Close()
+		return nil, nil, err
+	}
+
+	return ch, msgs, nil
+}
+
+func (c *Consumer) run(ctx context.Context, ch consumeChannel, msgs <-chan amqp.Delivery) {
+	defer close(c.done)
+
+	bo := newBackoff(c.rabbitMQ.reconnectMinBackoff, c.rabbitMQ.reconnectMaxBackoff)
+
+	for {
+		c.serve(ctx, ch, msgs)
+
+		if ctx.Err() != nil {
+			c.logger.Info("Consumer stopped", zap.String("consumer_tag", c.tag))
+			return
+		}
+
+		c.logger.Warn("Consumer deliveries closed, waiting for the channel to recover")
+
+		for {
+			var err error
+			if ch, msgs, err = c.register(ctx); err == nil {
+				c.logger.Info("Consumer re-registered", zap.String("consumer_tag", c.tag))
+				bo.reset()
+				break
+			}
+
+			if ctx.Err() != nil || errors.Is(err, ErrServiceClosed) {
+				c.logger.Info("Consumer stopped", zap.String("consumer_tag", c.tag))
+				return
+			}
+
+			delay := bo.next()
+			c.logger.Error("Failed to re-register consumer", zap.Duration("retry_in", delay), zap.Error(err))
+
+			select {
+			case <-ctx.Done():
+				c.logger.Info("Consumer stopped", zap.String("consumer_tag", c.tag))
+				return
+			case <-time.After(delay):
+			}
+		}
+	}
+}
+
+// serve handles deliveries on the worker pool until msgs is closed or ctx is done, and closes ch before
+// returning.
+func (c *Consumer) serve(ctx context.Context, ch consumeChannel, msgs <-chan amqp.Delivery) {
+	c.registered.Store(true)
+	defer func() {
+		c.registered.Store(false)
+		_ = ch.Close()
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < c.concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for msg := range msgs {
+				c.handle(msg)
+			}
+		}()
+	}
+
+	drained := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(drained)
+	}()
+
+	select {
+	case <-ctx.Done():
+		if err := ch.Cancel(c.tag, false); err != nil {
+			c.logger.Warn("Failed to cancel consumer", zap.String("consumer_tag", c.tag), zap.Error(err))
+		}
+
+		// the client closes msgs once the deliveries it already buffered have been handed over, so the
+		// workers exit after draining them
+		<-drained
+	case <-drained:
+	}
+}
+
+func (c *Consumer) handle(msg amqp.Delivery) {
+	metrics.MessagesConsumed.WithLabelValues(c.queue).Inc()
+
+	// not derived from the consumer's context: a message being handled when the consumer stops is finished
+	ctx, span := tracing.Tracer().Start(tracing.Extract(context.Background(), msg.Headers), fmt.Sprintf("%s process", c.queue),
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "rabbitmq"),
+			attribute.String("messaging.operation", "process"),
+			attribute.String("messaging.source.name", c.queue),
+			attribute.String("messaging.rabbitmq.destination.routing_key", msg.RoutingKey),
+			attribute.String("messaging.message.id", msg.MessageId),
+			attribute.Int("messaging.rabbitmq.retry_attempt", RetryAttempt(msg.Headers)),
+		),
+	)
+	defer span.End()
+
+	log := c.messageLogger(msg)
+	ctx = logger.NewContext(ctx, log)
+
+	err := c.invoke(ctx, msg)
+	tracing.RecordError(span, err)
+
+	switch {
+	case err == nil:
+		c.settled(metrics.SettleOutcomeAcked)
+		if ackErr := msg.Ack(false); ackErr != nil {
+			log.Error("Failed to ack message", zap.Error(ackErr))
+		}
+	case IsPermanent(err):
+		c.reject(log, msg, "Failed to process message, rejecting", err)
+	case c.retry != nil:
+		c.scheduleRetry(ctx, msg, err)
+	case msg.Redelivered:
+		// without delay queues to bound the attempts, requeueing every failure would redeliver it forever
+		c.reject(log, msg, "Failed to process redelivered message, rejecting", err)
+	default:
+		c.requeue(ctx, msg, err)
+	}
+}
+
+// messageLogger returns the logger for the log lines about msg, carrying its message and correlation IDs.
+func (c *Consumer) messageLogger(msg amqp.Delivery) *zap.Logger {
+	fields := []zap.Field{zap.String("message_id", msg.MessageId)}
+	if msg.CorrelationId != "" {
+		fields = append(fields, zap.String("correlation_id", msg.CorrelationId))
+	}
+	return c.logger.With(fields...)
+}
+
+func (c *Consumer) scheduleRetry(ctx context.Context, msg amqp.Delivery, cause error) {
+	ctx, cancel := context.WithTimeout(ctx, retryPublishTimeout)
+	defer cancel()
+
+	log := logger.FromContext(ctx, c.logger)
+	attempt := RetryAttempt(msg.Headers) + 1
+
+	parked, err := c.rabbitMQ.retry(ctx, c.retry, msg, cause)
+	if err != nil {
+		log.Error("Failed to schedule retry", zap.Int("attempt", attempt), zap.Error(err))
+		c.requeue(ctx, msg, cause)
+		return
+	}
+
+	if parked {
+		log.Error("Failed to process message, retries exhausted, parked", zap.Int("attempt", attempt), zap.Error(cause))
+		c.settled(metrics.SettleOutcomeParked)
+	} else {
+		log.Warn("Failed to process message, retry scheduled", zap.Int("attempt", attempt), zap.Error(cause))
+		c.settled(metrics.SettleOutcomeRetried)
+	}
+
+	if ackErr := msg.Ack(false); ackErr != nil {
+		log.Error("Failed to ack message", zap.Error(ackErr))
+	}
+}
+
+func (c *Consumer) requeue(ctx context.Context, msg amqp.Delivery, cause error) {
+	log := logger.FromContext(ctx, c.logger)
+	log.Warn("Failed to process message, requeueing", zap.Bool("redelivered", msg.Redelivered), zap.Error(cause))
+	c.settled(metrics.SettleOutcomeRequeued)
+	if nackErr := msg.Nack(false, true); nackErr != nil {
+		log.Error("Failed to nack message", zap.Error(nackErr))
+	}
+}
+
+func (c *Consumer) reject(log *zap.Logger, msg amqp.Delivery, message string, cause error) {
+	log.Error(message, zap.Error(cause))
+	c.settled(metrics.SettleOutcomeRejected)
+	if rejectErr := msg.Reject(false); rejectErr != nil {
+		log.Error("Failed to reject message", zap.Error(rejectErr))
+	}
+}
+
+func (c *Consumer) settled(outcome string) {
+	metrics.MessagesSettled.WithLabelValues(c.queue, outcome).Inc()
+}
+
+// invoke calls the handler, turning a panic into a permanent error.
+func (c *Consumer) invoke(ctx context.Context, msg amqp.Delivery) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			logger.FromContext(ctx, c.logger).Error("Recovered from panic in message handler", zap.Any("panic", recovered), zap.Stack("stack"))
+			err = Permanent(fmt.Errorf("handler panicked: %v", recovered))
+		}
+	}()
+
+	return c.handler(ctx, msg)
+}
@@ -0,0 +1,466 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/constants"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/zap"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeAcknowledger records how a delivery was settled.
+type fakeAcknowledger struct {
+	mu      sync.Mutex
+	settles []string
+}
+
+func (a *fakeAcknowledger) Ack(_ uint64, _ bool) error {
+	a.record("ack")
+	return nil
+}
+
+func (a *fakeAcknowledger) Nack(_ uint64, _ bool, requeue bool) error {
+	if requeue {
+		a.record("requeue")
+	} else {
+		a.record("nack")
+	}
+	return nil
+}
+
+func (a *fakeAcknowledger) Reject(_ uint64, requeue bool) error {
+	if requeue {
+		a.record("requeue")
+	} else {
+		a.record("reject")
+	}
+	return nil
+}
+
+func (a *fakeAcknowledger) record(settle string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.settles = append(a.settles, settle)
+}
+
+func (a *fakeAcknowledger) settled() []string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return append([]string(nil), a.settles...)
+}
+
+func TestConsumerSettlesWithoutRetryPolicy(t *testing.T) {
+	failure := errors.New("provider timeout")
+
+	tests := []struct {
+		name        string
+		handler     MessageHandler
+		redelivered bool
+		want        string
+	}{
+		{name: "success", handler: func(context.Context, amqp.Delivery) error { return nil }, want: "ack"},
+		{name: "permanent", handler: func(context.Context, amqp.Delivery) error { return Permanent(failure) }, want: "reject"},
+		{name: "panic", handler: func(context.Context, amqp.Delivery) error { panic("boom") }, want: "reject"},
+		{name: "retryable", handler: func(context.Context, amqp.Delivery) error { return Retryable(failure) }, want: "requeue"},
+		{name: "plain error", handler: func(context.Context, amqp.Delivery) error { return failure }, want: "requeue"},
+		{name: "retryable redelivered", handler: func(context.Context, amqp.Delivery) error { return Retryable(failure) }, redelivered: true, want: "reject"},
+		{name: "plain error redelivered", handler: func(context.Context, amqp.Delivery) error { return failure }, redelivered: true, want: "reject"},
+		{name: "success redelivered", handler: func(context.Context, amqp.Delivery) error { return nil }, redelivered: true, want: "ack"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Consumer{queue: "queue_test", handler: tt.handler, logger: zap.NewNop()}
+			acknowledger := &fakeAcknowledger{}
+
+			c.handle(amqp.Delivery{Acknowledger: acknowledger, MessageId: "n-1", Redelivered: tt.redelivered})
+
+			if got := acknowledger.settled(); len(got) != 1 || got[0] != tt.want {
+				t.Errorf("settled %v, want [%s]", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeConsumeChannel stands in for the channel of a subscription. Like the client, it closes the deliveries
+// once the subscription is cancelled or the channel is closed.
+type fakeConsumeChannel struct {
+	mu         sync.Mutex
+	prefetch   int
+	queue      string
+	tag        string
+	deliveries chan amqp.Delivery
+	cancelled  bool
+	closed     bool
+	ended      bool // whether the deliveries are closed
+}
+
+func (f *fakeConsumeChannel) Qos(prefetchCount, _ int, _ bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.prefetch = prefetchCount
+	return nil
+}
+
+func (f *fakeConsumeChannel) Consume(queue, consumer string, _, _, _, _ bool, _ amqp.Table) (<-chan amqp.Delivery, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.queue, f.tag = queue, consumer
+	return f.deliveries, nil
+}
+
+func (f *fakeConsumeChannel) Cancel(_ string, _ bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.cancelled = true
+	f.closeDeliveries()
+	return nil
+}
+
+func (f *fakeConsumeChannel) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+	f.closeDeliveries()
+	return nil
+}
+
+// lose closes the deliveries as the client does when the broker closes the channel or the connection.
+func (f *fakeConsumeChannel) lose() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closeDeliveries()
+}
+
+func (f *fakeConsumeChannel) closeDeliveries() {
+	if !f.ended {
+		f.ended = true
+		close(f.deliveries)
+	}
+}
+
+// fakeConsumeBroker opens the channels of a consumer, failing the first failures attempts.
+type fakeConsumeBroker struct {
+	mu       sync.Mutex
+	failures int
+	err      error
+	channels []*fakeConsumeChannel
+	opened   chan *fakeConsumeChannel
+}
+
+func newFakeConsumeBroker() *fakeConsumeBroker {
+	return &fakeConsumeBroker{err: ErrNotConnected, opened: make(chan *fakeConsumeChannel, 10)}
+}
+
+func (b *fakeConsumeBroker) open(_ context.Context) (consumeChannel, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.failures > 0 {
+		b.failures--
+		return nil, b.err
+	}
+
+	ch := &fakeConsumeChannel{deliveries: make(chan amqp.Delivery, 10)}
+	b.channels = append(b.channels, ch)
+	b.opened <- ch
+	return ch, nil
+}
+
+func (b *fakeConsumeBroker) failNext(failures int, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.failures, b.err = failures, err
+}
+
+// newTestConsumer returns a consumer of queue_test built like Consume builds them, on channels opened by
+// broker.
+func newTestConsumer(ctx context.Context, broker *fakeConsumeBroker, handler MessageHandler) (*Consumer, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	return &Consumer{
+		rabbitMQ: &RabbitMQService{
+			logger:              zap.NewNop(),
+			reconnectMinBackoff: 10 * time.Millisecond,
+			reconnectMaxBackoff: 50 * time.Millisecond,
+		},
+		open:        broker.open,
+		queue:       "queue_test",
+		tag:         "queue_test-1",
+		handler:     handler,
+		concurrency: 2,
+		prefetch:    4,
+		logger:      zap.NewNop(),
+		cancel:      cancel,
+		done:        make(chan struct{}),
+	}, ctx
+}
+
+func awaitChannel(t *testing.T, broker *fakeConsumeBroker) *fakeConsumeChannel {
+	t.Helper()
+
+	select {
+	case ch := <-broker.opened:
+		return ch
+	case <-time.After(time.Second):
+		t.Fatal("no channel opened")
+		return nil
+	}
+}
+
+func awaitHandled(t *testing.T, handled <-chan string, want string) {
+	t.Helper()
+
+	select {
+	case got := <-handled:
+		if got != want {
+			t.Fatalf("handled %s, want %s", got, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("%s not handled", want)
+	}
+}
+
+func TestConsumerRegistersAgainAfterChannelLoss(t *testing.T) {
+	broker := newFakeConsumeBroker()
+	handled := make(chan string, 10)
+
+	c, ctx := newTestConsumer(context.Background(), broker, func(_ context.Context, msg amqp.Delivery) error {
+		handled <- msg.MessageId
+		return nil
+	})
+	if err := c.start(ctx); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	defer c.Stop()
+
+	first := awaitChannel(t, broker)
+	first.deliveries <- amqp.Delivery{Acknowledger: &fakeAcknowledger{}, MessageId: "n-1"}
+	awaitHandled(t, handled, "n-1")
+
+	// the channel goes away and can't be opened again right away
+	broker.failNext(2, ErrNotConnected)
+	first.lose()
+
+	second := awaitChannel(t, broker)
+	second.deliveries <- amqp.Delivery{Acknowledger: &fakeAcknowledger{}, MessageId: "n-2"}
+	awaitHandled(t, handled, "n-2")
+
+	if err := c.Check(context.Background()); err != nil {
+		t.Errorf("Check after recovery = %v, want nil", err)
+	}
+
+	first.mu.Lock()
+	if !first.closed {
+		t.Error("lost channel not closed")
+	}
+	first.mu.Unlock()
+
+	second.mu.Lock()
+	if second.queue != "queue_test" || second.tag != "queue_test-1" || second.prefetch != 4 {
+		t.Errorf("registered again on %s as %s with prefetch %d", second.queue, second.tag, second.prefetch)
+	}
+	second.mu.Unlock()
+}
+
+func TestConsumerStop(t *testing.T) {
+	broker := newFakeConsumeBroker()
+	release := make(chan struct{})
+	handled := make(chan string, 10)
+
+	c, ctx := newTestConsumer(context.Background(), broker, func(_ context.Context, msg amqp.Delivery) error {
+		<-release
+		handled <- msg.MessageId
+		return nil
+	})
+	if err := c.start(ctx); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	ch := awaitChannel(t, broker)
+	acknowledger := &fakeAcknowledger{}
+	ch.deliveries <- amqp.Delivery{Acknowledger: acknowledger, MessageId: "n-1"}
+
+	stopped := make(chan struct{})
+	go func() {
+		c.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		t.Fatal("Stop returned before the delivery in flight was handled")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	<-stopped
+
+	awaitHandled(t, handled, "n-1")
+	if got := acknowledger.settled(); len(got) != 1 || got[0] != "ack" {
+		t.Errorf("in-flight delivery settled %v, want [ack]", got)
+	}
+
+	ch.mu.Lock()
+	if !ch.cancelled || !ch.closed {
+		t.Errorf("cancelled %v, closed %v, want both", ch.cancelled, ch.closed)
+	}
+	ch.mu.Unlock()
+
+	if err := c.Check(context.Background()); err == nil {
+		t.Error("Check of a stopped consumer = nil, want an error")
+	}
+}
+
+func TestConsumerStopsWhenServiceCloses(t *testing.T) {
+	broker := newFakeConsumeBroker()
+
+	c, ctx := newTestConsumer(context.Background(), broker, func(context.Context, amqp.Delivery) error { return nil })
+	if err := c.start(ctx); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	broker.failNext(1, ErrServiceClosed)
+	awaitChannel(t, broker).lose()
+
+	select {
+	case <-c.Done():
+	case <-time.After(time.Second):
+		t.Fatal("consumer kept trying to register on a closed service")
+	}
+}
+
+func TestConsumerRetriesThroughDelayQueues(t *testing.T) {
+	failure := errors.New("provider timeout")
+
+	tests := []struct {
+		name           string
+		err            error
+		attempt        int32
+		parkingLot     string
+		wantRoutingKey string // of the republished message, none when empty
+		wantSettle     string
+	}{
+		{name: "first failure", err: Retryable(failure), wantRoutingKey: "queue_test_retry_10s", wantSettle: "ack"},
+		{name: "plain error", err: failure, attempt: 1, wantRoutingKey: "queue_test_retry_1m", wantSettle: "ack"},
+		{name: "retries exhausted", err: Retryable(failure), attempt: 2, wantRoutingKey: "queue_test_parking_lot", wantSettle: "ack"},
+		{name: "permanent", err: Permanent(failure), wantSettle: "reject"},
+		{name: "retry not confirmed", err: Retryable(failure), attempt: 2, parkingLot: routingKeyNacked, wantRoutingKey: routingKeyNacked, wantSettle: "requeue"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parkingLot := tt.parkingLot
+			if parkingLot == "" {
+				parkingLot = "queue_test_parking_lot"
+			}
+
+			pubCh := &fakeConfirmChannel{}
+			c := &Consumer{
+				rabbitMQ: newConnectedService(pubCh),
+				queue:    "queue_test",
+				handler:  func(context.Context, amqp.Delivery) error { return tt.err },
+				retry: &RetryPolicy{
+					Queue:           "queue_test",
+					Exchange:        "exchange_test_retry",
+					ParkingLotQueue: parkingLot,
+					Delays:          []time.Duration{10 * time.Second, time.Minute},
+				},
+				logger: zap.NewNop(),
+			}
+			acknowledger := &fakeAcknowledger{}
+
+			c.handle(amqp.Delivery{
+				Acknowledger: acknowledger,
+				MessageId:    "n-1",
+				Headers:      amqp.Table{string(constants.HeaderRetryAttempt): tt.attempt},
+				Body:         []byte(`{"id": "n-1"}`),
+			})
+
+			if got := acknowledger.settled(); len(got) != 1 || got[0] != tt.wantSettle {
+				t.Errorf("settled %v, want [%s]", got, tt.wantSettle)
+			}
+
+			published := pubCh.publishings()
+			if tt.wantRoutingKey == "" {
+				if len(published) != 0 {
+					t.Errorf("republished %d messages, want none", len(published))
+				}
+				return
+			}
+			if len(published) != 1 {
+				t.Fatalf("republished %d messages, want 1", len(published))
+			}
+
+			p := published[0]
+			if p.exchange != "exchange_test_retry" || p.routingKey != tt.wantRoutingKey {
+				t.Errorf("republished to %s with %s, want exchange_test_retry with %s", p.exchange, p.routingKey, tt.wantRoutingKey)
+			}
+			if got := RetryAttempt(p.msg.Headers); got != int(tt.attempt)+1 {
+				t.Errorf("retry attempt %d, want %d", got, tt.attempt+1)
+			}
+			if p.msg.Headers[string(constants.HeaderLastError)] != tt.err.Error() || string(p.msg.Body) != `{"id": "n-1"}` {
+				t.Errorf("republished %+v", p.msg)
+			}
+		})
+	}
+}
+
+func TestConsumerConcurrency(t *testing.T) {
+	broker := newFakeConsumeBroker()
+	started := make(chan string, 10)
+	release := make(chan struct{})
+
+	c, ctx := newTestConsumer(context.Background(), broker, func(_ context.Context, msg amqp.Delivery) error {
+		started <- msg.MessageId
+		<-release
+		return nil
+	})
+	if err := c.start(ctx); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+
+	ch := awaitChannel(t, broker)
+	for _, id := range []string{"n-1", "n-2", "n-3"} {
+		ch.deliveries <- amqp.Delivery{Acknowledger: &fakeAcknowledger{}, MessageId: id}
+	}
+
+	// two workers, so the third delivery waits for one of them
+	for i := 0; i < 2; i++ {
+		select {
+		case <-started:
+		case <-time.After(time.Second):
+			t.Fatalf("%d deliveries handled concurrently, want 2", i)
+		}
+	}
+	select {
+	case id := <-started:
+		t.Fatalf("%s handled while both workers were busy", id)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("third delivery not handled once a worker was free")
+	}
+
+	c.Stop()
+}
+
+func TestConsumeRejectsConcurrencyBelowOne(t *testing.T) {
+	r := &RabbitMQService{logger: zap.NewNop()}
+
+	if _, err := r.Consume(context.Background(), "queue_test", func(context.Context, amqp.Delivery) error { return nil }, WithConcurrency(0)); err == nil {
+		t.Error("Consume accepted a concurrency of 0")
+	}
+}
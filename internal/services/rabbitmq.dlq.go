@@ -0,0 +1,331 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/constants"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/mask"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/zap"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrUnknownDeadLetterQueue is returned for a queue that is not one of the dead-letter queues.
+	ErrUnknownDeadLetterQueue = errors.New("rabbitmq: not a dead-letter queue")
+	// ErrMessageNotFound is returned when no message with the requested ID was found in the queue.
+	ErrMessageNotFound = errors.New("rabbitmq: message not found")
+
+	errStopBrowsing = errors.New("stop browsing")
+)
+
+// MaxDeadLetterScan bounds how many messages a dead-letter queue is scanned for in a single request.
+const MaxDeadLetterScan = 1000
+
+// DeadLetterQueues returns the queues holding notifications that could not be processed: messages rejected
+// by the worker and messages whose retries were exhausted.
+func (r *RabbitMQService) DeadLetterQueues() []string {
+	return []string{
+		string(constants.QueueNotificationDeadLetter),
+		string(constants.QueueNotificationParkingLot),
+	}
+}
+
+func (r *RabbitMQService) isDeadLetterQueue(queue string) bool {
+	for _, q := range r.DeadLetterQueues() {
+		if q == queue {
+			return true
+		}
+	}
+	return false
+}
+
+// ListDeadLetters returns up to limit messages from the head of a dead-letter queue without removing them.
+func (r *RabbitMQService) ListDeadLetters(ctx context.Context, queue string, limit int) ([]*models.DeadLetterMessage, error) {
+	if !r.isDeadLetterQueue(queue) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDeadLetterQueue, queue)
+	}
+
+	messages := make([]*models.DeadLetterMessage, 0)
+
+	if err := r.browse(ctx, queue, limit, func(msg amqp.Delivery) (bool, error) {
+		messages = append(messages, toDeadLetterMessage(queue, msg))
+		return false, nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// GetDeadLetter looks a message up by ID among the first MaxDeadLetterScan messages of a dead-letter queue.
+func (r *RabbitMQService) GetDeadLetter(ctx context.Context, queue, messageID string) (*models.DeadLetterMessage, error) {
+	if !r.isDeadLetterQueue(queue) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDeadLetterQueue, queue)
+	}
+
+	var found *models.DeadLetterMessage
+
+	if err := r.browse(ctx, queue, MaxDeadLetterScan, func(msg amqp.Delivery) (bool, error) {
+		if found == nil && msg.MessageId == messageID {
+			found = toDeadLetterMessage(queue, msg)
+		}
+		return false, nil
+	}); err != nil {
+		return nil, err
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("%w: %s", ErrMessageNotFound, messageID)
+	}
+
+	return found, nil
+}
+
+// ReplayDeadLetters republishes the requested messages to the notification exchange, with their payload
+// replaced when an override is given, and removes them from the dead-letter queue once the broker has
+// confirmed the republished copy. The replay is recorded in the message headers and the audit log.
+func (r *RabbitMQService) ReplayDeadLetters(ctx context.Context, request *models.ReplayDeadLettersRequest, actor string) (*models.ReplayDeadLettersResponse, error) {
+	queue := request.Queue
+	if queue == "" {
+		queue = string(constants.QueueNotificationDeadLetter)
+	}
+
+	if !r.isDeadLetterQueue(queue) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDeadLetterQueue, queue)
+	}
+
+	pending := make(map[string]bool, len(request.MessageIDs))
+	for _, id := range request.MessageIDs {
+		pending[id] = true
+	}
+
+	response := &models.ReplayDeadLettersResponse{
+		Replayed:   make([]string, 0, len(pending)),
+		ReplayedBy: actor,
+		ReplayedAt: time.Now().UTC(),
+	}
+
+	err := r.browse(ctx, queue, MaxDeadLetterScan, func(msg amqp.Delivery) (bool, error) {
+		if !pending[msg.MessageId] {
+			return false, nil
+		}
+
+		publishing, err := replayPublishing(msg, request.Overrides[msg.MessageId], response)
+		if err != nil {
+			return false, err
+		}
+
+		if err := r.publishConfirmed(ctx, string(constants.ExchangeNotification), string(constants.RoutingKeyNotification), publishing, false); err != nil {
+			return false, fmt.Errorf("replay message %s: %w", msg.MessageId, err)
+		}
+
+		delete(pending, msg.MessageId)
+		response.Replayed = append(response.Replayed, msg.MessageId)
+
+		// the rest of the queue needn't be scanned once every requested message has been replayed
+		if len(pending) == 0 {
+			return true, errStopBrowsing
+		}
+
+		return true, nil
+	})
+
+	if len(response.Replayed) > 0 {
+		r.logger.Info("Audit: dead letters replayed",
+			zap.String("queue", queue),
+			zap.Strings("message_ids", response.Replayed),
+			zap.String("replayed_by", actor),
+			zap.Time("replayed_at", response.ReplayedAt),
+		)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for id := range pending {
+		response.NotFound = append(response.NotFound, id)
+	}
+
+	return response, nil
+}
+
+// PurgeDeadLetters drops every message of a dead-letter queue and returns how many were dropped.
+func (r *RabbitMQService) PurgeDeadLetters(ctx context.Context, queue, actor string) (int, error) {
+	if !r.isDeadLetterQueue(queue) {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownDeadLetterQueue, queue)
+	}
+
+	ch, err := r.openChannel(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = ch.Close()
+	}()
+
+	purged, err := ch.QueuePurge(queue, false)
+	if err != nil {
+		return 0, fmt.Errorf("purge queue %s: %w", queue, err)
+	}
+
+	r.logger.Info("Audit: dead letters purged", zap.String("queue", queue), zap.Int("purged", purged), zap.String("purged_by", actor))
+
+	return purged, nil
+}
+
+// browse fetches up to limit messages from queue with basic.get on a channel of its own and passes them to
+// visit, which returns whether the message should be acked, i.e. removed from the queue, and errStopBrowsing
+// to end early after it. Messages that are not acked are requeued in their original order when the channel
+// is closed.
+func (r *RabbitMQService) browse(ctx context.Context, queue string, limit int, visit func(msg amqp.Delivery) (bool, error)) error {
+	ch, err := r.openChannel(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = ch.Close()
+	}()
+
+	for i := 0; i < limit; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		msg, ok, err := ch.Get(queue, false)
+		if err != nil {
+			return fmt.Errorf("get from queue %s: %w", queue, err)
+		}
+		if !ok {
+			return nil
+		}
+
+		ack, err := visit(msg)
+		stop := errors.Is(err, errStopBrowsing)
+		if err != nil && !stop {
+			return err
+		}
+
+		if ack {
+			if err := msg.Ack(false); err != nil {
+				return fmt.Errorf("ack message %s: %w", msg.MessageId, err)
+			}
+		}
+
+		if stop {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func replayPublishing(msg amqp.Delivery, override *models.Notification, response *models.ReplayDeadLettersResponse) (amqp.Publishing, error) {
+	body := msg.Body
+	if override != nil {
+		override.ID = msg.MessageId
+		override.Status = models.NotificationStatusPending
+		override.Error = ""
+		override.SentAt = nil
+
+		var err error
+		if body, err = json.Marshal(override); err != nil {
+			return amqp.Publishing{}, fmt.Errorf("marshal override for message %s: %w", msg.MessageId, err)
+		}
+	}
+
+	headers := amqp.Table{}
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	// a replayed message starts over with a fresh retry budget
+	delete(headers, string(constants.HeaderRetryAttempt))
+	delete(headers, string(constants.HeaderLastError))
+	headers[string(constants.HeaderReplayedBy)] = response.ReplayedBy
+	headers[string(constants.HeaderReplayedAt)] = response.ReplayedAt.Format(time.RFC3339)
+
+	return amqp.Publishing{
+		Headers:         headers,
+		ContentType:     msg.ContentType,
+		ContentEncoding: msg.ContentEncoding,
+		DeliveryMode:    amqp.Persistent,
+		CorrelationId:   msg.CorrelationId,
+		MessageId:       msg.MessageId,
+		Timestamp:       msg.Timestamp,
+		Type:            msg.Type,
+		AppId:           msg.AppId,
+		Body:            body,
+	}, nil
+}
+
+func toDeadLetterMessage(queue string, msg amqp.Delivery) *models.DeadLetterMessage {
+	message := &models.DeadLetterMessage{
+		MessageID:    msg.MessageId,
+		Queue:        queue,
+		Headers:      msg.Headers,
+		RetryAttempt: RetryAttempt(msg.Headers),
+	}
+
+	if !msg.Timestamp.IsZero() {
+		publishedAt := msg.Timestamp
+		message.PublishedAt = &publishedAt
+	}
+
+	var notification models.Notification
+	if err := json.Unmarshal(msg.Body, &notification); err == nil {
+		mask.Sensitive(&notification)
+		message.Notification = &notification
+	} else if isJSON(msg.ContentType) || strings.HasPrefix(strings.TrimSpace(string(msg.Body)), "{") {
+		// as when peeking, a JSON body that couldn't be decoded may hold the fields that masking hides
+		message.BodyOmitted = true
+	} else {
+		message.Body = string(msg.Body)
+	}
+
+	if lastError, ok := msg.Headers[string(constants.HeaderLastError)].(string); ok {
+		message.LastError = lastError
+	}
+
+	deaths, _ := msg.Headers[string(constants.HeaderDeath)].([]interface{})
+	for _, d := range deaths {
+		table, ok := d.(amqp.Table)
+		if !ok {
+			continue
+		}
+
+		death := models.DeadLetterDeath{}
+		death.Queue, _ = table["queue"].(string)
+		death.Exchange, _ = table["exchange"].(string)
+		death.Reason, _ = table["reason"].(string)
+		death.Count, _ = table["count"].(int64)
+		if t, ok := table["time"].(time.Time); ok {
+			death.Time = &t
+		}
+		if keys, ok := table["routing-keys"].([]interface{}); ok {
+			for _, key := range keys {
+				if s, ok := key.(string); ok {
+					death.RoutingKeys = append(death.RoutingKeys, s)
+				}
+			}
+		}
+
+		message.Deaths = append(message.Deaths, death)
+	}
+
+	// without a recorded handler error, the reason of the most recent death is the best explanation we have
+	if message.LastError == "" && len(message.Deaths) > 0 {
+		message.LastError = message.Deaths[0].Reason
+	}
+
+	return message
+}
@@ -0,0 +1,51 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package services
+
+import (
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/constants"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"testing"
+)
+
+func TestToDeadLetterMessage(t *testing.T) {
+	notification := `{"id": "n-1", "type": "email", "recipient": "kiet@example.com", "message": "hi", "meta_data": {"phone": "+84912345678"}}`
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantBody    string
+		wantOmitted bool
+		wantParsed  bool
+	}{
+		{name: "notification", contentType: "application/json", body: notification, wantParsed: true},
+		{name: "invalid JSON", contentType: "application/json", body: `{"recipient": "kiet@example.com",`, wantOmitted: true},
+		{name: "JSON without content type", body: `{"recipient": "kiet@example.com",`, wantOmitted: true},
+		{name: "plain text", contentType: "text/plain", body: "hello", wantBody: "hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := toDeadLetterMessage(string(constants.QueueNotificationDeadLetter), amqp.Delivery{
+				MessageId:   "n-1",
+				ContentType: tt.contentType,
+				Body:        []byte(tt.body),
+				Headers:     amqp.Table{string(constants.HeaderLastError): "smtp: connection refused"},
+			})
+
+			if message.Body != tt.wantBody || message.BodyOmitted != tt.wantOmitted || (message.Notification != nil) != tt.wantParsed {
+				t.Errorf("toDeadLetterMessage = body %q, omitted %v, notification %v", message.Body, message.BodyOmitted, message.Notification)
+			}
+			if tt.wantParsed && (message.Notification.Recipient == "kiet@example.com" || message.Notification.MetaData["phone"] == "+84912345678") {
+				t.Errorf("notification not masked: %+v", message.Notification)
+			}
+			if message.LastError != "smtp: connection refused" {
+				t.Errorf("LastError = %q", message.LastError)
+			}
+		})
+	}
+}
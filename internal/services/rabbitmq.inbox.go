@@ -0,0 +1,213 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/config"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/constants"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/management"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrInvalidRecipient is returned for a recipient that can't name an inbox.
+	ErrInvalidRecipient = errors.New("rabbitmq: invalid inbox recipient")
+	// ErrTooManyInboxes is returned when a new inbox would exceed INBOX_MAX_INBOXES.
+	ErrTooManyInboxes = errors.New("rabbitmq: too many inboxes")
+	// ErrInboxQueue is returned when the queue of an inbox is read by someone other than its recipient.
+	ErrInboxQueue = errors.New("rabbitmq: inbox queues are only readable by their recipient")
+)
+
+// maxRecipientLength keeps the inbox queue name within the 255 bytes allowed by AMQP.
+const maxRecipientLength = 200
+
+// InboxQueue returns the queue holding the inbox of recipient.
+func InboxQueue(recipient string) (string, error) {
+	if recipient == "" || len(recipient) > maxRecipientLength {
+		return "", fmt.Errorf("%w: must be 1 to %d bytes", ErrInvalidRecipient, maxRecipientLength)
+	}
+	return string(constants.QueueInboxPrefix) + recipient, nil
+}
+
+// InboxRecipient returns the recipient whose inbox queue is, if it is one.
+func InboxRecipient(queue string) (string, bool) {
+	return strings.CutPrefix(queue, string(constants.QueueInboxPrefix))
+}
+
+// MaxInboxMessages returns how many messages an inbox holds, INBOX_MAX_MESSAGES; the oldest ones are
+// dropped to make room for new ones.
+func MaxInboxMessages() int {
+	return config.GetInt("INBOX_MAX_MESSAGES", 100)
+}
+
+// DeliverToInbox stores notification in the inbox of its recipient, a durable queue declared on first use
+// and deleted after INBOX_EXPIRES_MS without being read or written. Since anyone allowed to send
+// notifications can name any recipient, at most INBOX_MAX_INBOXES inboxes are created; past that,
+// notifications to new recipients fail with ErrTooManyInboxes until older inboxes expire. Changing
+// INBOX_MAX_MESSAGES or INBOX_EXPIRES_MS requires deleting the existing inbox queues, whose arguments can't
+// be changed.
+func (r *RabbitMQService) DeliverToInbox(ctx context.Context, notification *models.Notification) error {
+	queue, err := InboxQueue(notification.Recipient)
+	if err != nil {
+		return err
+	}
+
+	if err := r.checkInboxCapacity(ctx, queue); err != nil {
+		return err
+	}
+
+	ch, err := r.openChannel(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = ch.Close()
+	}()
+
+	if _, err := ch.QueueDeclare(queue, true, false, false, false, amqp.Table{
+		"x-max-length": int64(MaxInboxMessages()),
+		"x-overflow":   "drop-head",
+		"x-expires":    int64(config.GetInt("INBOX_EXPIRES_MS", 30*24*60*60*1000)),
+	}); err != nil {
+		return fmt.Errorf("declare inbox queue %s: %w", queue, err)
+	}
+
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	// published through the default exchange, which routes to the queue of the same name
+	if err := r.publishConfirmed(ctx, "", queue, amqp.Publishing{
+		ContentType:  "application/json",
+		Body:         body,
+		DeliveryMode: amqp.Persistent,
+		Timestamp:    time.Now(),
+		MessageId:    notification.ID,
+	}, false); err != nil {
+		return fmt.Errorf("failed to publish to inbox: %w", err)
+	}
+
+	return nil
+}
+
+// ListInbox returns up to limit messages of the inbox of recipient, oldest first, without removing them.
+// An inbox that was never written to, or has expired, is empty.
+func (r *RabbitMQService) ListInbox(ctx context.Context, recipient string, limit int) ([]*models.InboxMessage, error) {
+	queue, err := InboxQueue(recipient)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]*models.InboxMessage, 0)
+
+	err = r.browse(ctx, queue, limit, func(msg amqp.Delivery) (bool, error) {
+		messages = append(messages, toInboxMessage(msg))
+		return false, nil
+	})
+	if isNotFound(err) {
+		return messages, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// DeleteInboxMessage removes the message with ID messageID from the inbox of recipient, e.g. once it has
+// been read.
+func (r *RabbitMQService) DeleteInboxMessage(ctx context.Context, recipient, messageID string) error {
+	queue, err := InboxQueue(recipient)
+	if err != nil {
+		return err
+	}
+
+	found := false
+
+	err = r.browse(ctx, queue, MaxInboxMessages(), func(msg amqp.Delivery) (bool, error) {
+		if found {
+			return false, errStopBrowsing
+		}
+		if msg.MessageId != messageID {
+			return false, nil
+		}
+		found = true
+		return true, nil
+	})
+	if err != nil && !isNotFound(err) {
+		return err
+	}
+
+	if !found {
+		return fmt.Errorf("%w: %s", ErrMessageNotFound, messageID)
+	}
+
+	return nil
+}
+
+// checkInboxCapacity returns ErrTooManyInboxes when queue doesn't exist yet and INBOX_MAX_INBOXES inbox
+// queues already do. Workers check concurrently, so the limit can be exceeded by a few inboxes.
+func (r *RabbitMQService) checkInboxCapacity(ctx context.Context, queue string) error {
+	_, err := r.management.GetQueue(ctx, queue)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, management.ErrNotFound) {
+		return fmt.Errorf("get inbox queue %s: %w", queue, err)
+	}
+
+	page, err := r.management.ListQueues(ctx, &management.ListParams{
+		Page:     1,
+		PageSize: 1,
+		Name:     "^" + regexp.QuoteMeta(string(constants.QueueInboxPrefix)),
+		UseRegex: true,
+		Columns:  []string{"name"},
+	})
+	if err != nil {
+		return fmt.Errorf("count inbox queues: %w", err)
+	}
+
+	if limit := config.GetInt("INBOX_MAX_INBOXES", 10000); page.FilteredCount >= limit {
+		return fmt.Errorf("%w: %d inboxes exist, INBOX_MAX_INBOXES is %d", ErrTooManyInboxes, page.FilteredCount, limit)
+	}
+
+	return nil
+}
+
+func toInboxMessage(msg amqp.Delivery) *models.InboxMessage {
+	message := &models.InboxMessage{
+		ID: msg.MessageId,
+	}
+
+	if !msg.Timestamp.IsZero() {
+		receivedAt := msg.Timestamp
+		message.ReceivedAt = &receivedAt
+	}
+
+	var notification models.Notification
+	if err := json.Unmarshal(msg.Body, &notification); err == nil {
+		message.Title = notification.Title
+		message.Message = notification.Message
+		message.MetaData = notification.MetaData
+	}
+
+	return message
+}
+
+// isNotFound reports whether err is the channel exception raised for a queue that doesn't exist.
+func isNotFound(err error) bool {
+	var amqpErr *amqp.Error
+	return errors.As(err, &amqpErr) && amqpErr.Code == amqp.NotFound
+}
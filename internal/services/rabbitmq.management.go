@@ -0,0 +1,283 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/management"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/mask"
+	"mime"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// MaxPeekMessages bounds how many messages PeekMessages fetches, each of them is requeued by the broker.
+	MaxPeekMessages = 100
+	// peekTruncateBytes bounds the body size of a peeked message, notifications are far smaller.
+	peekTruncateBytes = 50000
+	// exchangeBindingsConcurrency bounds the binding requests GetListExchanges sends at once.
+	exchangeBindingsConcurrency = 8
+)
+
+func (r *RabbitMQService) GetListQueues(ctx context.Context, params *management.ListParams) (*models.QueueList, error) {
+	page, err := r.management.ListQueues(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	queues := make([]*models.Queue, 0, len(page.Items))
+	for i := range page.Items {
+		queues = append(queues, toQueue(&page.Items[i]))
+	}
+
+	return &models.QueueList{
+		Items:  queues,
+		Paging: toPaging(page),
+	}, nil
+}
+
+// GetListExchanges returns a page of exchanges, each with the bindings it is the source of. The bindings are
+// fetched for the exchanges of the page only, exchangeBindingsConcurrency at a time.
+func (r *RabbitMQService) GetListExchanges(ctx context.Context, params *management.ListParams) (*models.ExchangeList, error) {
+	page, err := r.management.ListExchanges(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	exchanges := make([]*models.Exchange, len(page.Items))
+	errs := make([]error, len(page.Items))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, exchangeBindingsConcurrency)
+
+	for i := range page.Items {
+		exchanges[i] = toExchange(&page.Items[i])
+
+		// the bindings of the default exchange are implicit, one per queue
+		if exchanges[i].Name == "" {
+			continue
+		}
+
+		wg.Add(1)
+		go func(exchange *models.Exchange, err *error) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			bindings, listErr := r.management.ListExchangeBindings(ctx, exchange.Name)
+			if listErr != nil {
+				// deleted since the page was listed
+				if !errors.Is(listErr, management.ErrNotFound) {
+					*err = fmt.Errorf("get bindings of exchange %s: %w", exchange.Name, listErr)
+				}
+				return
+			}
+
+			for j := range bindings {
+				exchange.Bindings = append(exchange.Bindings, *toBinding(&bindings[j]))
+			}
+		}(exchanges[i], &errs[i])
+	}
+
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	return &models.ExchangeList{
+		Items:  exchanges,
+		Paging: toPaging(page),
+	}, nil
+}
+
+func (r *RabbitMQService) GetListBindings(ctx context.Context) ([]*models.Binding, error) {
+	data, err := r.management.ListBindings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bindings := make([]*models.Binding, 0, len(data))
+	for i := range data {
+		bindings = append(bindings, toBinding(&data[i]))
+	}
+
+	return bindings, nil
+}
+
+// PeekMessages fetches up to count messages from the head of a queue and puts them back. JSON bodies are
+// decoded into notifications with their sensitive fields masked. The broker flags the messages as
+// redelivered, and a requeued message may end up behind messages published in the meantime. The queue of
+// an inbox is only peeked at when canReadInbox, which may be nil, accepts its recipient; otherwise
+// ErrInboxQueue is returned.
+func (r *RabbitMQService) PeekMessages(ctx context.Context, queue string, count int, canReadInbox func(recipient string) bool) ([]*models.QueueMessage, error) {
+	if recipient, ok := InboxRecipient(queue); ok && (canReadInbox == nil || !canReadInbox(recipient)) {
+		return nil, fmt.Errorf("%w: %s", ErrInboxQueue, queue)
+	}
+
+	data, err := r.management.GetMessages(ctx, queue, management.GetMessagesRequest{
+		Count:    count,
+		AckMode:  management.AckModeRequeue,
+		Encoding: "auto",
+		Truncate: peekTruncateBytes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get messages of queue %s: %w", queue, err)
+	}
+
+	messages := make([]*models.QueueMessage, 0, len(data))
+	for i := range data {
+		messages = append(messages, toQueueMessage(&data[i]))
+	}
+
+	return messages, nil
+}
+
+func toQueueMessage(m *management.Message) *models.QueueMessage {
+	message := &models.QueueMessage{
+		Exchange:    m.Exchange,
+		RoutingKey:  m.RoutingKey,
+		Redelivered: m.Redelivered,
+		Properties: models.MessageProperties{
+			ContentType:     m.Properties.ContentType,
+			ContentEncoding: m.Properties.ContentEncoding,
+			DeliveryMode:    m.Properties.DeliveryMode,
+			Priority:        m.Properties.Priority,
+			CorrelationID:   m.Properties.CorrelationID,
+			ReplyTo:         m.Properties.ReplyTo,
+			Expiration:      m.Properties.Expiration,
+			MessageID:       m.Properties.MessageID,
+			Type:            m.Properties.Type,
+			UserID:          m.Properties.UserID,
+			AppID:           m.Properties.AppID,
+		},
+		Headers:   m.Properties.Headers,
+		BodyBytes: m.PayloadBytes,
+		Truncated: m.PayloadBytes > peekTruncateBytes,
+	}
+
+	if m.Properties.Timestamp > 0 {
+		timestamp := time.Unix(m.Properties.Timestamp, 0).UTC()
+		message.Properties.Timestamp = &timestamp
+	}
+
+	looksJSON := isJSON(m.Properties.ContentType) || (m.PayloadEncoding == "string" && strings.HasPrefix(strings.TrimSpace(m.Payload), "{"))
+
+	if looksJSON && m.PayloadEncoding == "string" && !message.Truncated {
+		var notification models.Notification
+		if err := json.Unmarshal([]byte(m.Payload), &notification); err == nil {
+			mask.Sensitive(&notification)
+			message.Notification = &notification
+			return message
+		}
+	}
+
+	// a JSON body that wasn't decoded, e.g. because it was truncated, may hold the fields that masking hides
+	if looksJSON {
+		message.BodyOmitted = true
+		return message
+	}
+
+	message.Body = m.Payload
+	message.BodyEncoding = m.PayloadEncoding
+
+	return message
+}
+
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))
+}
+
+// CheckManagement reports whether the management API is reachable with the configured credentials.
+func (r *RabbitMQService) CheckManagement(ctx context.Context) error {
+	return r.management.Ping(ctx)
+}
+
+func toPaging[T any](page *management.Page[T]) models.Paging {
+	return models.Paging{
+		Page:          page.Page,
+		PageSize:      page.PageSize,
+		PageCount:     page.PageCount,
+		ItemCount:     page.ItemCount,
+		FilteredCount: page.FilteredCount,
+		TotalCount:    page.TotalCount,
+	}
+}
+
+func toQueue(q *management.Queue) *models.Queue {
+	queueType := q.Type
+	if queueType == "" {
+		// brokers before 3.8 only have classic queues and don't report a type
+		queueType = "classic"
+	}
+
+	return &models.Queue{
+		Name:                   q.Name,
+		VHost:                  q.VHost,
+		Type:                   queueType,
+		State:                  q.State,
+		Durable:                q.Durable,
+		AutoDelete:             q.AutoDelete,
+		Exclusive:              q.Exclusive,
+		Messages:               q.Messages,
+		MessagesReady:          q.MessagesReady,
+		MessagesUnacknowledged: q.MessagesUnacknowledged,
+		Consumers:              q.Consumers,
+		MessageRates: models.QueueMessageRates{
+			Publish:   q.MessageStats.PublishDetails.Rate,
+			Deliver:   q.MessageStats.DeliverGetDetails.Rate,
+			Ack:       q.MessageStats.AckDetails.Rate,
+			Redeliver: q.MessageStats.RedeliverDetails.Rate,
+		},
+		Arguments: nonNilArgs(q.Arguments),
+		Policy:    q.Policy,
+	}
+}
+
+func toExchange(e *management.Exchange) *models.Exchange {
+	return &models.Exchange{
+		Name:       e.Name,
+		VHost:      e.VHost,
+		Type:       e.Type,
+		Durable:    e.Durable,
+		AutoDelete: e.AutoDelete,
+		Internal:   e.Internal,
+		MessageRates: models.ExchangeMessageRates{
+			PublishIn:  e.MessageStats.PublishInDetails.Rate,
+			PublishOut: e.MessageStats.PublishOutDetails.Rate,
+		},
+		Arguments: nonNilArgs(e.Arguments),
+		Policy:    e.Policy,
+		Bindings:  make([]models.Binding, 0),
+	}
+}
+
+func toBinding(b *management.Binding) *models.Binding {
+	return &models.Binding{
+		Source:          b.Source,
+		Destination:     b.Destination,
+		DestinationType: b.DestinationType,
+		VHost:           b.VHost,
+		RoutingKey:      b.RoutingKey,
+		Arguments:       nonNilArgs(b.Arguments),
+		PropertiesKey:   b.PropertiesKey,
+	}
+}
+
+func nonNilArgs(args map[string]any) map[string]any {
+	if args == nil {
+		return map[string]any{}
+	}
+	return args
+}
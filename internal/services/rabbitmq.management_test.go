@@ -0,0 +1,78 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package services
+
+import (
+	"context"
+	"errors"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/management"
+	"strings"
+	"testing"
+)
+
+func TestPeekMessagesRefusesInboxQueues(t *testing.T) {
+	r := &RabbitMQService{}
+
+	tests := []struct {
+		name         string
+		canReadInbox func(string) bool
+	}{
+		{name: "no inbox access"},
+		{name: "another recipient", canReadInbox: func(recipient string) bool { return recipient == "user-43" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := r.PeekMessages(context.Background(), "queue_inbox.user-42", 10, tt.canReadInbox); !errors.Is(err, ErrInboxQueue) {
+				t.Errorf("PeekMessages error = %v, want %v", err, ErrInboxQueue)
+			}
+		})
+	}
+}
+
+func TestToQueueMessage(t *testing.T) {
+	notification := `{"id": "n-1", "type": "email", "recipient": "kiet@example.com", "message": "hi", "meta_data": {"phone": "+84912345678"}}`
+
+	tests := []struct {
+		name            string
+		contentType     string
+		payload         string
+		encoding        string
+		payloadBytes    int
+		wantBody        string
+		wantOmitted     bool
+		wantParsed      bool
+		wantNoPlaintext bool
+	}{
+		{name: "notification", contentType: "application/json", payload: notification, encoding: "string", payloadBytes: len(notification), wantParsed: true, wantNoPlaintext: true},
+		{name: "truncated notification", contentType: "application/json", payload: notification[:60], encoding: "string", payloadBytes: peekTruncateBytes + 1, wantOmitted: true, wantNoPlaintext: true},
+		{name: "invalid JSON", contentType: "application/json", payload: `{"recipient": "kiet@example.com",`, encoding: "string", payloadBytes: 33, wantOmitted: true, wantNoPlaintext: true},
+		{name: "JSON without content type", payload: notification, encoding: "string", payloadBytes: len(notification), wantParsed: true, wantNoPlaintext: true},
+		{name: "base64 JSON", contentType: "application/json", payload: "eyJyZWNpcGllbnQiOiAia2lldEBleGFtcGxlLmNvbSJ9", encoding: "base64", payloadBytes: 33, wantOmitted: true},
+		{name: "plain text", contentType: "text/plain", payload: "hello", encoding: "string", payloadBytes: 5, wantBody: "hello"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			message := toQueueMessage(&management.Message{
+				Properties:      management.MessageProperties{ContentType: tt.contentType},
+				Payload:         tt.payload,
+				PayloadEncoding: tt.encoding,
+				PayloadBytes:    tt.payloadBytes,
+			})
+
+			if message.Body != tt.wantBody || message.BodyOmitted != tt.wantOmitted || (message.Notification != nil) != tt.wantParsed {
+				t.Errorf("toQueueMessage = body %q, omitted %v, notification %v", message.Body, message.BodyOmitted, message.Notification)
+			}
+			if tt.wantParsed && (message.Notification.Recipient == "kiet@example.com" || message.Notification.MetaData["phone"] == "+84912345678") {
+				t.Errorf("notification not masked: %+v", message.Notification)
+			}
+			if tt.wantNoPlaintext && strings.Contains(message.Body, "kiet@example.com") {
+				t.Errorf("body shows the recipient: %q", message.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,431 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/constants"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/management"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/zap"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// MaxMoveScan bounds how many messages a move job fetches. Messages skipped by the filter stay unacked on
+	// the job's channel until it finishes, so this also bounds how many the job holds on to.
+	MaxMoveScan = 10000
+	// moveJobRetention is how long a finished move job stays available through GetMoveJob.
+	moveJobRetention = time.Hour
+)
+
+// ErrJobNotFound is returned for an unknown or expired move job.
+var ErrJobNotFound = errors.New("rabbitmq: job not found")
+
+type moveJob struct {
+	mu     sync.Mutex
+	job    models.MoveJob
+	cancel context.CancelFunc
+}
+
+func (j *moveJob) update(fn func(job *models.MoveJob)) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	fn(&j.job)
+	j.job.UpdatedAt = time.Now().UTC()
+}
+
+func (j *moveJob) snapshot() *models.MoveJob {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	job := j.job
+	return &job
+}
+
+// StartMove starts a job that moves messages from queue to the target exchange and routing key, like a
+// shovel would: each message is republished with a confirm and only then acked on the source queue. The job
+// runs in the background until the source queue is drained, MaxMessages or MaxMoveScan is reached, it fails
+// or it is cancelled. Its progress is reported by GetMoveJob. Jobs live in the memory of the service that
+// started them: other API replicas don't know their IDs, and a restart forgets them.
+//
+// A target that routes the messages back to the source queue, directly or through bindings, is refused.
+func (r *RabbitMQService) StartMove(ctx context.Context, queue string, request *models.MoveMessagesRequest, actor string) (*models.MoveJob, error) {
+	if request.MaxMessages < 0 || request.RatePerSecond < 0 {
+		return nil, fmt.Errorf("%w: max_messages and rate_per_second can't be negative", ErrInvalidDefinition)
+	}
+	if request.TargetExchange == "" && request.TargetRoutingKey == "" {
+		return nil, fmt.Errorf("%w: missing target_exchange or target_routing_key", ErrInvalidDefinition)
+	}
+
+	if _, err := r.management.GetQueue(ctx, queue); err != nil {
+		return nil, fmt.Errorf("get queue %s: %w", queue, err)
+	}
+	if request.TargetExchange != "" {
+		if _, err := r.management.GetExchange(ctx, request.TargetExchange); err != nil {
+			return nil, fmt.Errorf("get exchange %s: %w", request.TargetExchange, err)
+		}
+	}
+
+	loops, err := r.routesTo(ctx, request.TargetExchange, request.TargetRoutingKey, queue)
+	if err != nil {
+		return nil, err
+	}
+	if loops {
+		return nil, fmt.Errorf("%w: the target routes messages back to queue %s", ErrInvalidDefinition, queue)
+	}
+
+	// the job outlives the request that started it
+	jobCtx, cancel := context.WithCancel(context.Background())
+
+	now := time.Now().UTC()
+	job := &moveJob{
+		job: models.MoveJob{
+			ID:               uuid.NewString(),
+			Status:           models.MoveJobStatusRunning,
+			SourceQueue:      queue,
+			TargetExchange:   request.TargetExchange,
+			TargetRoutingKey: request.TargetRoutingKey,
+			MaxMessages:      request.MaxMessages,
+			RatePerSecond:    request.RatePerSecond,
+			Filter:           request.Filter,
+			StartedBy:        actor,
+			StartedAt:        now,
+			UpdatedAt:        now,
+		},
+		cancel: cancel,
+	}
+
+	r.jobsMu.Lock()
+	for id, j := range r.moveJobs {
+		if finished := j.snapshot().FinishedAt; finished != nil && now.Sub(*finished) > moveJobRetention {
+			delete(r.moveJobs, id)
+		}
+	}
+	r.moveJobs[job.job.ID] = job
+	r.jobsMu.Unlock()
+
+	r.logger.Info("Audit: move started",
+		zap.String("job_id", job.job.ID),
+		zap.String("queue", queue),
+		zap.String("target_exchange", request.TargetExchange),
+		zap.String("target_routing_key", request.TargetRoutingKey),
+		zap.String("started_by", actor),
+	)
+
+	go r.runMove(jobCtx, job, request)
+
+	return job.snapshot(), nil
+}
+
+func (r *RabbitMQService) GetMoveJob(id string) (*models.MoveJob, error) {
+	r.jobsMu.Lock()
+	job, ok := r.moveJobs[id]
+	r.jobsMu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrJobNotFound, id)
+	}
+
+	return job.snapshot(), nil
+}
+
+// CancelMoveJob stops a running move job. Messages already moved stay moved, the rest stay in the source
+// queue.
+func (r *RabbitMQService) CancelMoveJob(id, actor string) (*models.MoveJob, error) {
+	r.jobsMu.Lock()
+	job, ok := r.moveJobs[id]
+	r.jobsMu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrJobNotFound, id)
+	}
+
+	job.cancel()
+	r.logger.Info("Audit: move cancelled", zap.String("job_id", id), zap.String("cancelled_by", actor))
+
+	return job.snapshot(), nil
+}
+
+func (r *RabbitMQService) runMove(ctx context.Context, job *moveJob, request *models.MoveMessagesRequest) {
+	defer job.cancel()
+
+	go func() {
+		select {
+		case <-r.done:
+			job.cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	var interval time.Duration
+	if request.RatePerSecond > 0 {
+		interval = time.Second / time.Duration(request.RatePerSecond)
+	}
+
+	queue := job.snapshot().SourceQueue
+	stopReason := "source queue drained"
+	var next time.Time
+
+	err := r.browse(ctx, queue, MaxMoveScan, func(msg amqp.Delivery) (bool, error) {
+		if request.MaxMessages > 0 && job.snapshot().Moved >= request.MaxMessages {
+			stopReason = "max_messages reached"
+			return false, errStopBrowsing
+		}
+
+		// the bindings changed since the job started and the target routes back to the queue
+		if jobID, _ := msg.Headers[string(constants.HeaderMoveJob)].(string); jobID == job.snapshot().ID {
+			stopReason = "moved messages routed back to the source queue"
+			return false, errStopBrowsing
+		}
+
+		if !matchesMoveFilter(msg, request.Filter) {
+			job.update(func(job *models.MoveJob) {
+				job.Scanned++
+				job.Skipped++
+			})
+			return false, nil
+		}
+
+		if interval > 0 {
+			select {
+			case <-ctx.Done():
+				return false, ctx.Err()
+			case <-time.After(time.Until(next)):
+			}
+			next = time.Now().Add(interval)
+		}
+
+		if err := r.publishConfirmed(ctx, request.TargetExchange, request.TargetRoutingKey, movePublishing(msg, queue, job.snapshot(), request.ResetRetries), false); err != nil {
+			return false, fmt.Errorf("move message %s: %w", msg.MessageId, err)
+		}
+
+		job.update(func(job *models.MoveJob) {
+			job.Scanned++
+			job.Moved++
+		})
+
+		return true, nil
+	})
+
+	snapshot := job.snapshot()
+	if err == nil && snapshot.Scanned >= MaxMoveScan {
+		stopReason = "scan limit reached"
+	}
+
+	job.update(func(job *models.MoveJob) {
+		finishedAt := time.Now().UTC()
+		job.FinishedAt = &finishedAt
+
+		switch {
+		case err == nil:
+			job.Status = models.MoveJobStatusCompleted
+			job.StopReason = stopReason
+		case errors.Is(err, context.Canceled):
+			job.Status = models.MoveJobStatusCancelled
+			job.StopReason = "cancelled"
+		default:
+			job.Status = models.MoveJobStatusFailed
+			job.Error = err.Error()
+		}
+	})
+
+	snapshot = job.snapshot()
+	r.logger.Info("Audit: move finished",
+		zap.String("job_id", snapshot.ID),
+		zap.String("queue", snapshot.SourceQueue),
+		zap.String("status", string(snapshot.Status)),
+		zap.Int("moved", snapshot.Moved),
+		zap.Int("skipped", snapshot.Skipped),
+		zap.String("error", snapshot.Error),
+	)
+}
+
+func movePublishing(msg amqp.Delivery, queue string, job *models.MoveJob, resetRetries bool) amqp.Publishing {
+	headers := amqp.Table{}
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	if resetRetries {
+		delete(headers, string(constants.HeaderRetryAttempt))
+		delete(headers, string(constants.HeaderLastError))
+	}
+	headers[string(constants.HeaderMovedFrom)] = queue
+	headers[string(constants.HeaderMovedBy)] = job.StartedBy
+	headers[string(constants.HeaderMoveJob)] = job.ID
+	headers[string(constants.HeaderMovedAt)] = time.Now().UTC().Format(time.RFC3339)
+
+	messageID := msg.MessageId
+	if messageID == "" {
+		messageID = uuid.NewString()
+	}
+
+	return amqp.Publishing{
+		Headers:         headers,
+		ContentType:     msg.ContentType,
+		ContentEncoding: msg.ContentEncoding,
+		DeliveryMode:    msg.DeliveryMode,
+		Priority:        msg.Priority,
+		CorrelationId:   msg.CorrelationId,
+		ReplyTo:         msg.ReplyTo,
+		Expiration:      msg.Expiration,
+		MessageId:       messageID,
+		Timestamp:       msg.Timestamp,
+		Type:            msg.Type,
+		UserId:          msg.UserId,
+		AppId:           msg.AppId,
+		Body:            msg.Body,
+	}
+}
+
+// matchesMoveFilter reports whether msg satisfies every condition of filter. A nil filter matches everything.
+func matchesMoveFilter(msg amqp.Delivery, filter *models.MoveFilter) bool {
+	if filter == nil {
+		return true
+	}
+
+	for key, expected := range filter.Headers {
+		value, ok := msg.Headers[key]
+		if !ok || fmt.Sprint(value) != expected {
+			return false
+		}
+	}
+
+	if len(filter.Fields) == 0 {
+		return true
+	}
+
+	var body any
+	if err := json.Unmarshal(msg.Body, &body); err != nil {
+		return false
+	}
+
+	for path, expected := range filter.Fields {
+		value, ok := lookupField(body, path)
+		if !ok || !reflect.DeepEqual(value, expected) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// lookupField follows a dot-separated path through decoded JSON objects.
+func lookupField(body any, path string) (any, bool) {
+	value := body
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+
+	return value, true
+}
+
+// routesTo reports whether a message published to exchange with routingKey may reach queue, following
+// exchange-to-exchange bindings and alternate exchanges. Headers exchanges and exchanges of plugins are
+// assumed to route to every destination bound to them.
+func (r *RabbitMQService) routesTo(ctx context.Context, exchange, routingKey, queue string) (bool, error) {
+	// the default exchange routes to the queue named by the routing key
+	if exchange == "" {
+		return routingKey == queue, nil
+	}
+
+	visited := make(map[string]bool)
+	pending := []string{exchange}
+
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+
+		if visited[name] {
+			continue
+		}
+		visited[name] = true
+
+		info, err := r.management.GetExchange(ctx, name)
+		if errors.Is(err, management.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("get exchange %s: %w", name, err)
+		}
+
+		if ae, _ := info.Arguments["alternate-exchange"].(string); ae != "" {
+			pending = append(pending, ae)
+		}
+
+		bindings, err := r.management.ListExchangeBindings(ctx, name)
+		if err != nil && !errors.Is(err, management.ErrNotFound) {
+			return false, fmt.Errorf("get bindings of exchange %s: %w", name, err)
+		}
+
+		for _, binding := range bindings {
+			if !bindingMatches(info.Type, binding.RoutingKey, routingKey) {
+				continue
+			}
+
+			switch binding.DestinationType {
+			case "queue":
+				if binding.Destination == queue {
+					return true, nil
+				}
+			case "exchange":
+				pending = append(pending, binding.Destination)
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// bindingMatches reports whether an exchange of type exchangeType routes a message with routingKey through a
+// binding with bindingKey.
+func bindingMatches(exchangeType, bindingKey, routingKey string) bool {
+	switch exchangeType {
+	case "direct":
+		return bindingKey == routingKey
+	case "topic":
+		return topicMatches(strings.Split(bindingKey, "."), strings.Split(routingKey, "."))
+	default:
+		return true
+	}
+}
+
+// topicMatches matches the words of a routing key against the words of a topic binding key, where * matches
+// exactly one word and # zero or more.
+func topicMatches(pattern, words []string) bool {
+	if len(pattern) == 0 {
+		return len(words) == 0
+	}
+
+	switch pattern[0] {
+	case "#":
+		for i := 0; i <= len(words); i++ {
+			if topicMatches(pattern[1:], words[i:]) {
+				return true
+			}
+		}
+		return false
+	case "*":
+		return len(words) > 0 && topicMatches(pattern[1:], words[1:])
+	default:
+		return len(words) > 0 && pattern[0] == words[0] && topicMatches(pattern[1:], words[1:])
+	}
+}
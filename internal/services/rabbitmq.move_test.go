@@ -0,0 +1,116 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/management"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTopicMatches(t *testing.T) {
+	tests := []struct {
+		pattern    string
+		routingKey string
+		want       bool
+	}{
+		{pattern: "notification.email", routingKey: "notification.email", want: true},
+		{pattern: "notification.email", routingKey: "notification.sms", want: false},
+		{pattern: "notification.*", routingKey: "notification.sms", want: true},
+		{pattern: "notification.*", routingKey: "notification", want: false},
+		{pattern: "notification.*", routingKey: "notification.sms.high", want: false},
+		{pattern: "notification.#", routingKey: "notification", want: true},
+		{pattern: "notification.#", routingKey: "notification.sms.high", want: true},
+		{pattern: "#", routingKey: "anything.at.all", want: true},
+		{pattern: "*.sms.#", routingKey: "notification.sms", want: true},
+		{pattern: "#.high", routingKey: "notification.sms.high", want: true},
+		{pattern: "#.high", routingKey: "notification.sms.low", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+" "+tt.routingKey, func(t *testing.T) {
+			if got := bindingMatches("topic", tt.pattern, tt.routingKey); got != tt.want {
+				t.Errorf("bindingMatches(topic, %q, %q) = %v, want %v", tt.pattern, tt.routingKey, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoutesTo(t *testing.T) {
+	// exchange_notification routes notification.* to queue_notification and everything else to its alternate
+	// exchange; exchange_retry forwards to exchange_notification
+	exchanges := map[string]management.Exchange{
+		"exchange_notification": {Name: "exchange_notification", Type: "topic", Arguments: map[string]any{"alternate-exchange": "exchange_unrouted"}},
+		"exchange_unrouted":     {Name: "exchange_unrouted", Type: "fanout"},
+		"exchange_retry":        {Name: "exchange_retry", Type: "direct"},
+		"exchange_other":        {Name: "exchange_other", Type: "direct"},
+	}
+	bindings := map[string][]management.Binding{
+		"exchange_notification": {{Source: "exchange_notification", Destination: "queue_notification", DestinationType: "queue", RoutingKey: "notification.*"}},
+		"exchange_unrouted":     {{Source: "exchange_unrouted", Destination: "queue_unrouted", DestinationType: "queue"}},
+		"exchange_retry": {
+			{Source: "exchange_retry", Destination: "exchange_notification", DestinationType: "exchange", RoutingKey: "retry"},
+			{Source: "exchange_retry", Destination: "exchange_retry", DestinationType: "exchange", RoutingKey: "retry"},
+		},
+		"exchange_other": {{Source: "exchange_other", Destination: "queue_other", DestinationType: "queue", RoutingKey: "other"}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, isBindings := strings.CutSuffix(strings.TrimPrefix(r.URL.EscapedPath(), "/api/exchanges/%2F/"), "/bindings/source")
+
+		var body any
+		if isBindings {
+			body = bindings[name]
+		} else if exchange, ok := exchanges[name]; ok {
+			body = exchange
+		} else {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(body)
+	}))
+	defer server.Close()
+
+	client, err := management.NewClient(management.Config{Address: server.URL, VHost: "%2F"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := &RabbitMQService{management: client}
+
+	tests := []struct {
+		name       string
+		exchange   string
+		routingKey string
+		queue      string
+		want       bool
+	}{
+		{name: "default exchange to itself", exchange: "", routingKey: "queue_notification", queue: "queue_notification", want: true},
+		{name: "default exchange elsewhere", exchange: "", routingKey: "queue_other", queue: "queue_notification"},
+		{name: "topic binding", exchange: "exchange_notification", routingKey: "notification.sms", queue: "queue_notification", want: true},
+		{name: "topic binding not matching", exchange: "exchange_notification", routingKey: "alert", queue: "queue_notification"},
+		{name: "alternate exchange", exchange: "exchange_notification", routingKey: "alert", queue: "queue_unrouted", want: true},
+		{name: "exchange to exchange", exchange: "exchange_retry", routingKey: "retry", queue: "queue_unrouted", want: true},
+		{name: "exchange to exchange not matching", exchange: "exchange_retry", routingKey: "other", queue: "queue_unrouted"},
+		{name: "unrelated exchange", exchange: "exchange_other", routingKey: "other", queue: "queue_notification"},
+		{name: "missing exchange", exchange: "exchange_missing", routingKey: "x", queue: "queue_notification"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.routesTo(context.Background(), tt.exchange, tt.routingKey, tt.queue)
+			if err != nil {
+				t.Fatalf("routesTo: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("routesTo(%q, %q, %q) = %v, want %v", tt.exchange, tt.routingKey, tt.queue, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,319 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/metrics"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/tracing"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/constants"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrUnroutable is returned when a mandatory message matched no binding and was returned by the broker.
+	ErrUnroutable = errors.New("rabbitmq: message unroutable")
+	// ErrPublishNacked is returned when the broker negatively acknowledged a message.
+	ErrPublishNacked = errors.New("rabbitmq: message nacked by broker")
+	// ErrNotConfirmed is returned when the context is done before the broker confirmed a message.
+	ErrNotConfirmed = errors.New("rabbitmq: message not confirmed")
+)
+
+// PublishWithConfirm publishes notification as a mandatory message on the confirm-mode channel and waits
+// until the broker has taken responsibility for it. It returns ErrUnroutable when the message matched no
+// queue, ErrPublishNacked when the broker refused it, and ErrNotConfirmed or ErrNotConnected when ctx is
+// done or the channel went away before the confirmation arrived.
+func (r *RabbitMQService) PublishWithConfirm(ctx context.Context, exchange, routingKey string, notification *models.Notification, opts ...PublishOption) error {
+	options := &publishOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return fmt.Errorf("failed to marshal notification: %w", err)
+	}
+
+	if err := r.publishConfirmed(ctx, exchange, routingKey, amqp.Publishing{
+		ContentType:   "application/json",
+		Body:          body,
+		DeliveryMode:  amqp.Persistent,
+		Timestamp:     time.Now(),
+		MessageId:     notification.ID,
+		CorrelationId: options.correlationID,
+	}, options.failFast); err != nil {
+		return fmt.Errorf("failed to publish message: %w", err)
+	}
+
+	r.logger.Info("Published notification with confirm", zap.String("id", notification.ID), zap.String("correlation_id", options.correlationID))
+
+	return nil
+}
+
+// PublishEvent publishes event as a persistent JSON message of type routingKey and, like PublishWithConfirm,
+// waits until the broker has taken responsibility for it.
+func (r *RabbitMQService) PublishEvent(ctx context.Context, exchange, routingKey string, event interface{}, opts ...PublishOption) error {
+	options := &publishOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	if err := r.publishConfirmed(ctx, exchange, routingKey, amqp.Publishing{
+		ContentType:   "application/json",
+		Body:          body,
+		DeliveryMode:  amqp.Persistent,
+		Timestamp:     time.Now(),
+		MessageId:     uuid.New().String(),
+		CorrelationId: options.correlationID,
+		Type:          routingKey,
+	}, options.failFast); err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+
+	return nil
+}
+
+// publishConfirmed publishes msg as a mandatory message on the confirm-mode channel and waits for the
+// broker's confirmation.
+func (r *RabbitMQService) publishConfirmed(ctx context.Context, exchange, routingKey string, msg amqp.Publishing, failFast bool) error {
+	ctx, span := startPublishSpan(ctx, exchange, routingKey, msg.MessageId)
+	defer span.End()
+
+	msg.Headers = tracing.Inject(ctx, msg.Headers)
+
+	err := r.publishAndConfirm(ctx, exchange, routingKey, msg, failFast)
+	metrics.MessagesPublished.WithLabelValues(exchange, routingKey, publishOutcome(err)).Inc()
+	tracing.RecordError(span, err)
+
+	return err
+}
+
+// startPublishSpan starts the producer span of a message published to exchange. The trace context of the
+// returned context is the one to inject into the message headers.
+func startPublishSpan(ctx context.Context, exchange, routingKey, messageID string) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, fmt.Sprintf("%s publish", exchange),
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "rabbitmq"),
+			attribute.String("messaging.operation", "publish"),
+			attribute.String("messaging.destination.name", exchange),
+			attribute.String("messaging.rabbitmq.destination.routing_key", routingKey),
+			attribute.String("messaging.message.id", messageID),
+		),
+	)
+}
+
+func publishOutcome(err error) string {
+	switch {
+	case err == nil:
+		return metrics.PublishOutcomeConfirmed
+	case errors.Is(err, ErrUnroutable):
+		return metrics.PublishOutcomeUnroutable
+	case errors.Is(err, ErrPublishNacked):
+		return metrics.PublishOutcomeNacked
+	case errors.Is(err, ErrNotConfirmed):
+		return metrics.PublishOutcomeNotConfirmed
+	case errors.Is(err, ErrNotConnected), errors.Is(err, ErrServiceClosed):
+		return metrics.PublishOutcomeNotConnected
+	default:
+		return metrics.PublishOutcomeError
+	}
+}
+
+func (r *RabbitMQService) publishAndConfirm(ctx context.Context, exchange, routingKey string, msg amqp.Publishing, failFast bool) error {
+	ch, err := r.publishChannel(ctx, failFast)
+	if err != nil {
+		return err
+	}
+
+	// returns are matched on an ID of their own, the message ID of a replayed or client-supplied message
+	// isn't unique among the publishes in flight
+	publishID := uuid.New().String()
+	msg.Headers[string(constants.HeaderPublishID)] = publishID
+
+	returned := ch.expectReturn(publishID)
+	defer ch.forgetReturn(publishID)
+
+	start := time.Now()
+
+	confirmation, err := ch.PublishWithDeferredConfirmWithContext(ctx, exchange, routingKey, true, false, msg)
+	if err != nil {
+		if errors.Is(err, amqp.ErrClosed) {
+			err = fmt.Errorf("%w: %w", ErrNotConnected, err)
+		}
+		return err
+	}
+
+	acked, err := ch.settle(ctx, confirmation.DeliveryTag)
+	if err != nil {
+		return err
+	}
+
+	metrics.PublishConfirmDuration.WithLabelValues(exchange).Observe(time.Since(start).Seconds())
+
+	if !acked {
+		return ErrPublishNacked
+	}
+
+	select {
+	case ret := <-returned:
+		return fmt.Errorf("%w: %d %s", ErrUnroutable, ret.ReplyCode, ret.ReplyText)
+	default:
+	}
+
+	return nil
+}
+
+// confirmModeChannel is the part of *amqp.Channel confirmChannel uses, so that tests can stand in for the
+// broker.
+type confirmModeChannel interface {
+	PublishWithDeferredConfirmWithContext(ctx context.Context, exchange, key string, mandatory, immediate bool, msg amqp.Publishing) (*amqp.DeferredConfirmation, error)
+	NotifyReturn(c chan amqp.Return) chan amqp.Return
+	NotifyPublish(confirm chan amqp.Confirmation) chan amqp.Confirmation
+	NotifyClose(c chan *amqp.Error) chan *amqp.Error
+	IsClosed() bool
+	Close() error
+}
+
+// confirmChannel is the confirm-mode publish channel, along with what's needed to tell the publishes
+// waiting on it whether the broker confirmed or returned their message.
+type confirmChannel struct {
+	confirmModeChannel
+
+	mu        sync.Mutex
+	returns   map[string]chan amqp.Return // by publish ID
+	confirmed uint64                      // delivery tag of the last confirm handled by dispatch
+	nacked    map[uint64]bool             // delivery tags nacked by the broker, until their publish settles
+	progress  chan struct{}               // closed when confirmed changes or dispatch stops, then replaced
+	stopped   bool
+	logger    *zap.Logger
+}
+
+func newConfirmChannel(ch confirmModeChannel, logger *zap.Logger) *confirmChannel {
+	c := &confirmChannel{
+		confirmModeChannel: ch,
+		returns:            make(map[string]chan amqp.Return),
+		nacked:             make(map[uint64]bool),
+		progress:           make(chan struct{}),
+		logger:             logger,
+	}
+
+	go c.dispatch(ch.NotifyReturn(make(chan amqp.Return)), ch.NotifyPublish(make(chan amqp.Confirmation)))
+
+	return c
+}
+
+// dispatch hands returned messages to the publishes waiting for them and records the confirms, which the
+// client delivers one by one and in order of delivery tag, even when the broker acks several at once. The broker
+// sends the return of a message before its ack, and the client blocks until each one is received, so by
+// the time dispatch handles the confirm of a message its return, if any, has been handed over. It stops
+// when the channel is closed.
+func (c *confirmChannel) dispatch(returns <-chan amqp.Return, confirms <-chan amqp.Confirmation) {
+	defer func() {
+		c.mu.Lock()
+		c.stopped = true
+		close(c.progress)
+		c.mu.Unlock()
+	}()
+
+	for returns != nil || confirms != nil {
+		select {
+		case ret, ok := <-returns:
+			if !ok {
+				returns = nil
+				continue
+			}
+
+			publishID, _ := ret.Headers[string(constants.HeaderPublishID)].(string)
+
+			c.mu.Lock()
+			returned, ok := c.returns[publishID]
+			c.mu.Unlock()
+
+			if !ok {
+				c.logger.Warn("Returned message has no pending publisher", zap.String("message_id", ret.MessageId), zap.String("exchange", ret.Exchange), zap.String("routing_key", ret.RoutingKey), zap.String("reason", ret.ReplyText))
+				continue
+			}
+
+			// buffered, a message is returned once
+			returned <- ret
+
+		case confirmation, ok := <-confirms:
+			if !ok {
+				confirms = nil
+				continue
+			}
+
+			c.mu.Lock()
+			c.confirmed = confirmation.DeliveryTag
+			if !confirmation.Ack {
+				c.nacked[confirmation.DeliveryTag] = true
+			}
+			close(c.progress)
+			c.progress = make(chan struct{})
+			c.mu.Unlock()
+		}
+	}
+}
+
+// expectReturn registers a publish, the returned channel receives its message if the broker returns it.
+func (c *confirmChannel) expectReturn(publishID string) <-chan amqp.Return {
+	returned := make(chan amqp.Return, 1)
+
+	c.mu.Lock()
+	c.returns[publishID] = returned
+	c.mu.Unlock()
+
+	return returned
+}
+
+func (c *confirmChannel) forgetReturn(publishID string) {
+	c.mu.Lock()
+	delete(c.returns, publishID)
+	c.mu.Unlock()
+}
+
+// settle waits until dispatch has handled the confirm of the message published with deliveryTag, and with
+// it any return of the message, and reports whether the broker acked it.
+func (c *confirmChannel) settle(ctx context.Context, deliveryTag uint64) (bool, error) {
+	for {
+		c.mu.Lock()
+		confirmed, stopped, progress := c.confirmed >= deliveryTag, c.stopped, c.progress
+		nacked := c.nacked[deliveryTag]
+		if confirmed {
+			delete(c.nacked, deliveryTag)
+		}
+		c.mu.Unlock()
+
+		switch {
+		case confirmed:
+			return !nacked, nil
+		case stopped:
+			return false, fmt.Errorf("%w: channel closed before confirmation", ErrNotConnected)
+		}
+
+		select {
+		case <-progress:
+		case <-ctx.Done():
+			return false, fmt.Errorf("%w: %w", ErrNotConfirmed, ctx.Err())
+		}
+	}
+}
@@ -0,0 +1,232 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/constants"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/zap"
+	"sync"
+	"testing"
+	"time"
+)
+
+// Routing keys the fake confirm-mode channel doesn't ack.
+const (
+	routingKeyNacked      = "nacked"
+	routingKeyUnroutable  = "unroutable"
+	routingKeyUnconfirmed = "unconfirmed"
+)
+
+type fakePublishing struct {
+	exchange   string
+	routingKey string
+	mandatory  bool
+	msg        amqp.Publishing
+}
+
+// fakeConfirmChannel stands in for a confirm-mode channel. Like the broker, it returns a mandatory message
+// that matches no queue before acking it, and confirms in order of delivery tag; which of these happen
+// depends on the routing key.
+type fakeConfirmChannel struct {
+	mu        sync.Mutex
+	tag       uint64
+	published []fakePublishing
+	returns   chan amqp.Return
+	confirms  chan amqp.Confirmation
+	closed    bool
+}
+
+func (f *fakeConfirmChannel) PublishWithDeferredConfirmWithContext(_ context.Context, exchange, key string, mandatory, _ bool, msg amqp.Publishing) (*amqp.DeferredConfirmation, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.closed {
+		return nil, amqp.ErrClosed
+	}
+
+	f.tag++
+	f.published = append(f.published, fakePublishing{exchange: exchange, routingKey: key, mandatory: mandatory, msg: msg})
+
+	switch key {
+	case routingKeyUnconfirmed:
+	case routingKeyUnroutable:
+		f.returns <- amqp.Return{ReplyCode: amqp.NoRoute, ReplyText: "NO_ROUTE", Exchange: exchange, RoutingKey: key, MessageId: msg.MessageId, Headers: msg.Headers}
+		f.confirms <- amqp.Confirmation{DeliveryTag: f.tag, Ack: true}
+	default:
+		f.confirms <- amqp.Confirmation{DeliveryTag: f.tag, Ack: key != routingKeyNacked}
+	}
+
+	return &amqp.DeferredConfirmation{DeliveryTag: f.tag}, nil
+}
+
+func (f *fakeConfirmChannel) NotifyReturn(c chan amqp.Return) chan amqp.Return {
+	f.returns = c
+	return c
+}
+
+func (f *fakeConfirmChannel) NotifyPublish(confirm chan amqp.Confirmation) chan amqp.Confirmation {
+	f.confirms = confirm
+	return confirm
+}
+
+func (f *fakeConfirmChannel) NotifyClose(c chan *amqp.Error) chan *amqp.Error {
+	return c
+}
+
+func (f *fakeConfirmChannel) IsClosed() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.closed
+}
+
+func (f *fakeConfirmChannel) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !f.closed {
+		f.closed = true
+		close(f.returns)
+		close(f.confirms)
+	}
+	return nil
+}
+
+func (f *fakeConfirmChannel) publishings() []fakePublishing {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]fakePublishing(nil), f.published...)
+}
+
+// newConnectedService returns a service whose publish channel is pubCh, as if it were connected.
+func newConnectedService(pubCh confirmModeChannel) *RabbitMQService {
+	ready := make(chan struct{})
+	close(ready)
+
+	return &RabbitMQService{
+		pubCh:               newConfirmChannel(pubCh, zap.NewNop()),
+		ready:               ready,
+		done:                make(chan struct{}),
+		logger:              zap.NewNop(),
+		reconnectMinBackoff: 10 * time.Millisecond,
+		reconnectMaxBackoff: 50 * time.Millisecond,
+	}
+}
+
+func TestPublishConfirmed(t *testing.T) {
+	tests := []struct {
+		name       string
+		routingKey string
+		closeAfter time.Duration // closes the channel while the publish waits for its confirm
+		wantErr    error
+	}{
+		{name: "acked", routingKey: "notification"},
+		{name: "nacked", routingKey: routingKeyNacked, wantErr: ErrPublishNacked},
+		{name: "returned", routingKey: routingKeyUnroutable, wantErr: ErrUnroutable},
+		{name: "not confirmed in time", routingKey: routingKeyUnconfirmed, wantErr: ErrNotConfirmed},
+		{name: "channel closed before the confirm", routingKey: routingKeyUnconfirmed, closeAfter: 20 * time.Millisecond, wantErr: ErrNotConnected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch := &fakeConfirmChannel{}
+			r := newConnectedService(ch)
+
+			if tt.closeAfter > 0 {
+				time.AfterFunc(tt.closeAfter, func() {
+					_ = ch.Close()
+				})
+			}
+
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+
+			err := r.publishConfirmed(ctx, "exchange_notification", tt.routingKey, amqp.Publishing{MessageId: "n-1"}, false)
+			if tt.wantErr == nil && err != nil || !errors.Is(err, tt.wantErr) {
+				t.Fatalf("publishConfirmed error = %v, want %v", err, tt.wantErr)
+			}
+
+			published := ch.publishings()
+			if len(published) != 1 {
+				t.Fatalf("published %d messages, want 1", len(published))
+			}
+			if p := published[0]; !p.mandatory || p.exchange != "exchange_notification" || p.msg.Headers[string(constants.HeaderPublishID)] == nil {
+				t.Errorf("published %+v, want a mandatory message with a publish ID", p)
+			}
+		})
+	}
+}
+
+func TestPublishConfirmedConcurrently(t *testing.T) {
+	ch := &fakeConfirmChannel{}
+	r := newConnectedService(ch)
+
+	// returns are matched to their publish by publish ID, even when they share a message ID
+	routingKeys := []string{"notification", routingKeyUnroutable, routingKeyNacked}
+	errs := make([]error, 30)
+
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = r.publishConfirmed(context.Background(), "exchange_notification", routingKeys[i%len(routingKeys)], amqp.Publishing{MessageId: "n-1"}, false)
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		var want error
+		switch routingKeys[i%len(routingKeys)] {
+		case routingKeyUnroutable:
+			want = ErrUnroutable
+		case routingKeyNacked:
+			want = ErrPublishNacked
+		}
+		if want == nil && err != nil || !errors.Is(err, want) {
+			t.Errorf("publish %d to %s: error = %v, want %v", i, routingKeys[i%len(routingKeys)], err, want)
+		}
+	}
+
+	r.pubCh.mu.Lock()
+	defer r.pubCh.mu.Unlock()
+	if len(r.pubCh.returns) != 0 || len(r.pubCh.nacked) != 0 {
+		t.Errorf("%d returns and %d nacks left pending", len(r.pubCh.returns), len(r.pubCh.nacked))
+	}
+}
+
+func TestPublishConfirmedFailFast(t *testing.T) {
+	r := newConnectedService(&fakeConfirmChannel{})
+	r.ready = make(chan struct{})
+
+	err := r.publishConfirmed(context.Background(), "exchange_notification", "notification", amqp.Publishing{}, true)
+	if !errors.Is(err, ErrNotConnected) {
+		t.Errorf("publishConfirmed while recovering = %v, want ErrNotConnected", err)
+	}
+}
+
+func TestPublishOutcome(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{err: nil, want: "confirmed"},
+		{err: fmt.Errorf("publish: %w", ErrUnroutable), want: "unroutable"},
+		{err: ErrPublishNacked, want: "nacked"},
+		{err: ErrNotConfirmed, want: "not_confirmed"},
+		{err: ErrServiceClosed, want: "not_connected"},
+		{err: errors.New("boom"), want: "error"},
+	}
+
+	for _, tt := range tests {
+		if got := publishOutcome(tt.err); got != tt.want {
+			t.Errorf("publishOutcome(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
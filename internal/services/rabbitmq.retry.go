@@ -0,0 +1,185 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/constants"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"strings"
+	"time"
+)
+
+// RetryPolicy routes deliveries of Queue whose handler failed with a retryable error through a chain of
+// delay queues. Each delay queue holds a message for its TTL and then dead-letters it back to
+// TargetExchange with TargetRoutingKey. Once every delay has been used the message is moved to
+// ParkingLotQueue instead.
+type RetryPolicy struct {
+	Queue            string
+	Exchange         string
+	TargetExchange   string
+	TargetRoutingKey string
+	ParkingLotQueue  string
+	Delays           []time.Duration
+}
+
+// ParseRetryDelays parses a comma separated list of durations such as "10s,1m,10m".
+func ParseRetryDelays(value string) ([]time.Duration, error) {
+	var delays []time.Duration
+
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		delay, err := time.ParseDuration(part)
+		if err != nil {
+			return nil, fmt.Errorf("parse retry delay %q: %w", part, err)
+		}
+		if delay < time.Millisecond {
+			return nil, fmt.Errorf("retry delay %q must be at least 1ms", part)
+		}
+
+		delays = append(delays, delay)
+	}
+
+	return delays, nil
+}
+
+// delayQueue names the delay queue after its TTL so that changing the configured delays declares new
+// queues instead of failing to redeclare existing ones with different arguments.
+func (p *RetryPolicy) delayQueue(i int) string {
+	return fmt.Sprintf("%s_retry_%s", p.Queue, formatDelay(p.Delays[i]))
+}
+
+func (p *RetryPolicy) topology() ([]*models.ExchangeConfig, []*models.QueueConfig, []*models.BindingConfig) {
+	exchanges := []*models.ExchangeConfig{
+		{
+			Name:       p.Exchange,
+			Type:       "direct",
+			Durable:    true,
+			AutoDelete: false,
+		},
+	}
+
+	queues := []*models.QueueConfig{
+		{
+			Name:       p.ParkingLotQueue,
+			Durable:    true,
+			AutoDelete: false,
+		},
+	}
+
+	bindings := []*models.BindingConfig{
+		{
+			Exchange:   p.Exchange,
+			Queue:      p.ParkingLotQueue,
+			RoutingKey: p.ParkingLotQueue,
+		},
+	}
+
+	for i, delay := range p.Delays {
+		queues = append(queues, &models.QueueConfig{
+			Name:       p.delayQueue(i),
+			Durable:    true,
+			AutoDelete: false,
+			Args: map[string]any{
+				"x-message-ttl":             delay.Milliseconds(),
+				"x-dead-letter-exchange":    p.TargetExchange,
+				"x-dead-letter-routing-key": p.TargetRoutingKey,
+			},
+		})
+
+		bindings = append(bindings, &models.BindingConfig{
+			Exchange:   p.Exchange,
+			Queue:      p.delayQueue(i),
+			RoutingKey: p.delayQueue(i),
+		})
+	}
+
+	return exchanges, queues, bindings
+}
+
+// retry republishes msg to the delay queue of its next attempt, or to the parking lot once the delays are
+// exhausted, recording the attempt count and cause in its headers. The caller acks msg on success.
+func (r *RabbitMQService) retry(ctx context.Context, policy *RetryPolicy, msg amqp.Delivery, cause error) (parked bool, err error) {
+	attempt := RetryAttempt(msg.Headers)
+
+	headers := amqp.Table{}
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	headers[string(constants.HeaderRetryAttempt)] = int32(attempt + 1)
+	headers[string(constants.HeaderLastError)] = cause.Error()
+
+	routingKey := policy.ParkingLotQueue
+	if attempt < len(policy.Delays) {
+		routingKey = policy.delayQueue(attempt)
+	}
+
+	messageID := msg.MessageId
+	if messageID == "" {
+		messageID = uuid.New().String()
+	}
+
+	if err := r.publishConfirmed(ctx, policy.Exchange, routingKey, amqp.Publishing{
+		Headers:         headers,
+		ContentType:     msg.ContentType,
+		ContentEncoding: msg.ContentEncoding,
+		DeliveryMode:    amqp.Persistent,
+		CorrelationId:   msg.CorrelationId,
+		MessageId:       messageID,
+		Timestamp:       msg.Timestamp,
+		Type:            msg.Type,
+		AppId:           msg.AppId,
+		Body:            msg.Body,
+	}, false); err != nil {
+		return false, fmt.Errorf("publish to %s: %w", routingKey, err)
+	}
+
+	return routingKey == policy.ParkingLotQueue, nil
+}
+
+// RetryAttempt returns the number of retries already made for a message, read from its headers.
+func RetryAttempt(headers amqp.Table) int {
+	switch v := headers[string(constants.HeaderRetryAttempt)].(type) {
+	case int:
+		return v
+	case int8:
+		return int(v)
+	case int16:
+		return int(v)
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case uint8:
+		return int(v)
+	case uint16:
+		return int(v)
+	case uint32:
+		return int(v)
+	default:
+		return 0
+	}
+}
+
+func formatDelay(d time.Duration) string {
+	switch {
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	case d%time.Minute == 0:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	case d%time.Second == 0:
+		return fmt.Sprintf("%ds", d/time.Second)
+	default:
+		return fmt.Sprintf("%dms", d.Milliseconds())
+	}
+}
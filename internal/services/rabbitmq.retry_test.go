@@ -0,0 +1,43 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package services
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseRetryDelays(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []time.Duration
+		wantErr bool
+	}{
+		{name: "list", value: "10s,1m,10m", want: []time.Duration{10 * time.Second, time.Minute, 10 * time.Minute}},
+		{name: "spaces and empty parts", value: " 500ms , ,1h30m,", want: []time.Duration{500 * time.Millisecond, 90 * time.Minute}},
+		{name: "minimum", value: "1ms", want: []time.Duration{time.Millisecond}},
+		{name: "empty", value: "", want: nil},
+		{name: "no unit", value: "10", wantErr: true},
+		{name: "invalid", value: "10s,soon", wantErr: true},
+		{name: "below 1ms", value: "500us", wantErr: true},
+		{name: "zero", value: "0s", wantErr: true},
+		{name: "negative", value: "-1s", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRetryDelays(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseRetryDelays(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParseRetryDelays(%q) = %v, want %v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
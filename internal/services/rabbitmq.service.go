@@ -6,269 +6,376 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/ngdangkietswe/go-rabbitmq/internal/config"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/metrics"
 	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/tracing"
 	"github.com/ngdangkietswe/go-rabbitmq/pkg/constants"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/management"
 	amqp "github.com/rabbitmq/amqp091-go"
 	"github.com/samber/lo"
 	"go.uber.org/zap"
-	"net/http"
+	"sync"
 	"time"
 )
 
 type RabbitMQService struct {
-	conn                   *amqp.Connection
-	ch                     *amqp.Channel
-	logger                 *zap.Logger
-	rabbitMQManagementAddr string
-	rabbitMQVHost          string
-	rabbitMQUsername       string
-	rabbitMQPassword       string
+	url                 string
+	mu                  sync.RWMutex
+	conn                *amqp.Connection
+	ch                  *amqp.Channel
+	pubCh               *confirmChannel // used by PublishWithConfirm
+	ready               chan struct{}   // closed while conn and ch are usable, replaced on disconnect
+	done                chan struct{}
+	closeOnce           sync.Once
+	logger              *zap.Logger
+	topology            *models.Topology
+	topologyMode        TopologyMode
+	retryPolicies       map[string]*RetryPolicy // by queue
+	reconnectMinBackoff time.Duration
+	reconnectMaxBackoff time.Duration
+	startupTimeout      time.Duration
+	management          *management.Client
+	jobsMu              sync.Mutex
+	moveJobs            map[string]*moveJob
 }
 
-func NewRabbitMQService(url string, logger *zap.Logger) (*RabbitMQService, error) {
-	conn, err := amqp.Dial(url)
-	if err != nil {
-		return nil, err
+type PublishOption func(*publishOptions)
+
+type publishOptions struct {
+	failFast      bool
+	correlationID string
+}
+
+// WithFailFast makes a publish return ErrNotConnected immediately while the connection is being recovered,
+// instead of waiting for it until the context is done.
+func WithFailFast() PublishOption {
+	return func(o *publishOptions) {
+		o.failFast = true
 	}
+}
 
-	ch, err := conn.Channel()
-	if err != nil {
-		return nil, err
+// WithCorrelationID sets the correlation ID of the message, typically the ID of the API request that
+// published it, so that the worker's log lines can be tied back to that request. The message ID remains the
+// notification ID, returned and dead-lettered messages are looked up by it.
+func WithCorrelationID(id string) PublishOption {
+	return func(o *publishOptions) {
+		o.correlationID = id
 	}
+}
 
+// NewRabbitMQService loads the topology and connects to the broker, retrying for up to
+// RABBITMQ_STARTUP_TIMEOUT_MS while it is unreachable or until ctx is done.
+func NewRabbitMQService(ctx context.Context, url string, logger *zap.Logger) (*RabbitMQService, error) {
 	service := &RabbitMQService{
-		conn:                   conn,
-		ch:                     ch,
-		logger:                 logger,
-		rabbitMQManagementAddr: config.GetString("RABBITMQ_MANAGEMENT_ADDRESS", "http://localhost:15672"),
-		rabbitMQVHost:          config.GetString("RABBITMQ_VHOST", "%2F"),
-		rabbitMQUsername:       config.GetString("RABBITMQ_USERNAME", "admin"),
-		rabbitMQPassword:       config.GetString("RABBITMQ_PASSWORD", "admin123"),
+		url:                 url,
+		ready:               make(chan struct{}),
+		done:                make(chan struct{}),
+		moveJobs:            make(map[string]*moveJob),
+		logger:              logger,
+		reconnectMinBackoff: time.Duration(config.GetInt("RABBITMQ_RECONNECT_MIN_BACKOFF_MS", 500)) * time.Millisecond,
+		reconnectMaxBackoff: time.Duration(config.GetInt("RABBITMQ_RECONNECT_MAX_BACKOFF_MS", 30000)) * time.Millisecond,
+		startupTimeout:      time.Duration(config.GetInt("RABBITMQ_STARTUP_TIMEOUT_MS", 60000)) * time.Millisecond,
 	}
 
-	exchanges := []*models.ExchangeConfig{
-		{
-			Name:       string(constants.ExchangeNotification),
-			Type:       "direct",
-			Durable:    true,
-			AutoDelete: false,
-		},
-		{
-			Name:       string(constants.ExchangeLog),
-			Type:       "fanout",
-			Durable:    true,
-			AutoDelete: false,
-		},
+	managementClient, err := management.NewClient(management.Config{
+		Address:               config.GetString("RABBITMQ_MANAGEMENT_ADDRESS", "http://localhost:15672"),
+		VHost:                 config.GetString("RABBITMQ_VHOST", "%2F"),
+		Username:              config.GetString("RABBITMQ_USERNAME", "admin"),
+		Password:              config.GetString("RABBITMQ_PASSWORD", "admin123"),
+		Timeout:               time.Duration(config.GetInt("RABBITMQ_MANAGEMENT_TIMEOUT_MS", 10000)) * time.Millisecond,
+		MaxRetries:            config.GetInt("RABBITMQ_MANAGEMENT_MAX_RETRIES", 2),
+		RetryBackoff:          time.Duration(config.GetInt("RABBITMQ_MANAGEMENT_RETRY_BACKOFF_MS", 200)) * time.Millisecond,
+		TLSCAFile:             config.GetString("RABBITMQ_MANAGEMENT_TLS_CA_FILE", ""),
+		TLSInsecureSkipVerify: config.GetBool("RABBITMQ_MANAGEMENT_TLS_INSECURE_SKIP_VERIFY", false),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create management client: %w", err)
 	}
 
-	if err := service.setupExchanges(exchanges); err != nil {
-		logger.Error("Failed to setup exchanges", zap.Error(err))
+	service.management = managementClient
+
+	topology, err := LoadTopology(config.GetString("RABBITMQ_TOPOLOGY_FILE", "./configs/topology.yaml"))
+	if err != nil {
 		return nil, err
 	}
 
-	queues := []*models.QueueConfig{
-		{
-			Name:       string(constants.QueueNotification),
-			Durable:    true,
-			AutoDelete: false,
-		},
-		{
-			Name:       string(constants.QueueLog),
-			Durable:    true,
-			AutoDelete: false,
-		},
+	service.topology = topology
+
+	service.topologyMode = TopologyMode(config.GetString("RABBITMQ_TOPOLOGY_MODE", string(TopologyModeDeclare)))
+	if service.topologyMode != TopologyModeDeclare && service.topologyMode != TopologyModeVerify {
+		return nil, fmt.Errorf("unknown topology mode %q, expected %q or %q", service.topologyMode, TopologyModeDeclare, TopologyModeVerify)
 	}
 
-	if err := service.setupQueues(queues); err != nil {
-		logger.Error("Failed to setup queues", zap.Error(err))
+	retryDelays, err := ParseRetryDelays(config.GetString("NOTIFICATION_RETRY_DELAYS", "10s,1m,10m"))
+	if err != nil {
 		return nil, err
 	}
 
-	bindings := []*models.BindingConfig{
-		{
-			Exchange:   string(constants.ExchangeNotification),
-			Queue:      string(constants.QueueNotification),
-			RoutingKey: string(constants.RoutingKeyNotification),
-		},
-		{
-			Exchange:   string(constants.ExchangeLog),
-			Queue:      string(constants.QueueLog),
-			RoutingKey: "",
-		},
+	service.retryPolicies = make(map[string]*RetryPolicy)
+
+	if len(retryDelays) > 0 {
+		notificationRetry := &RetryPolicy{
+			Queue:            string(constants.QueueNotification),
+			Exchange:         string(constants.ExchangeNotificationRetry),
+			TargetExchange:   string(constants.ExchangeNotification),
+			TargetRoutingKey: string(constants.RoutingKeyNotification),
+			ParkingLotQueue:  string(constants.QueueNotificationParkingLot),
+			Delays:           retryDelays,
+		}
+
+		exchanges, queues, bindings := notificationRetry.topology()
+		topology.Exchanges = append(topology.Exchanges, exchanges...)
+		topology.Queues = append(topology.Queues, queues...)
+		topology.Bindings = append(topology.Bindings, bindings...)
+		service.retryPolicies[notificationRetry.Queue] = notificationRetry
+	}
+
+	if err := ValidateTopology(topology); err != nil {
+		return nil, fmt.Errorf("invalid topology: %w", err)
 	}
 
-	if err := service.setupBindings(bindings); err != nil {
-		logger.Error("Failed to setup bindings", zap.Error(err))
+	conn, err := service.dialWithRetry(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// a broker that is up but refuses the topology won't change its mind, so this is not retried
+	if err := service.openChannels(conn); err != nil {
+		_ = conn.Close()
 		return nil, err
 	}
 
+	if service.topologyMode == TopologyModeVerify {
+		service.reportDrift()
+	}
+
+	go service.supervise()
+
 	return service, nil
 }
 
-func (r *RabbitMQService) setupExchanges(exchanges []*models.ExchangeConfig) error {
+// declareTopology declares the exchanges, queues and bindings the service depends on. It runs on every
+// (re)connect so that a restarted broker without persisted definitions gets them back. Every declaration
+// gets a fresh channel, since a failed one (e.g. PRECONDITION_FAILED on redeclare) closes its channel, and
+// all failures are reported together.
+func (r *RabbitMQService) declareTopology(conn *amqp.Connection) error {
+	if err := errors.Join(
+		r.setupExchanges(conn, r.topology.Exchanges),
+		r.setupQueues(conn, r.topology.Queues),
+		r.setupBindings(conn, r.topology.Bindings),
+		r.setupPolicies(r.topology.Policies),
+	); err != nil {
+		r.logger.Error("Failed to setup topology", zap.Error(err))
+		return fmt.Errorf("setup topology: %w", err)
+	}
+
+	return nil
+}
+
+// setupPolicies sets the policies through the management API. Policies are how settings such as the
+// dead-letter exchange reach queues that already exist, whose arguments can't be changed by redeclaring them.
+func (r *RabbitMQService) setupPolicies(policies []*models.PolicyConfig) error {
+	var errs []error
+
+	lo.ForEach(policies, func(policy *models.PolicyConfig, _ int) {
+		if err := r.management.SetPolicy(context.Background(), policy.Name, management.PolicySettings{
+			Pattern:    policy.Pattern,
+			ApplyTo:    policyApplyTo(policy),
+			Priority:   policy.Priority,
+			Definition: policy.Definition,
+		}); err != nil {
+			r.logger.Error("Failed to set policy", zap.String("policy", policy.Name), zap.Error(err))
+			errs = append(errs, fmt.Errorf("set policy %s: %w", policy.Name, err))
+		} else {
+			r.logger.Info("Set policy", zap.String("policy", policy.Name))
+		}
+	})
+
+	return errors.Join(errs...)
+}
+
+func (r *RabbitMQService) setupExchanges(conn *amqp.Connection, exchanges []*models.ExchangeConfig) error {
+	var errs []error
+
 	lo.ForEach(exchanges, func(exchange *models.ExchangeConfig, _ int) {
-		if err := r.ch.ExchangeDeclare(
-			exchange.Name,
-			exchange.Type,
-			exchange.Durable,
-			exchange.AutoDelete,
-			false,
-			false,
-			nil,
-		); err != nil {
+		if err := withChannel(conn, func(ch *amqp.Channel) error {
+			return ch.ExchangeDeclare(
+				exchange.Name,
+				exchange.Type,
+				exchange.Durable,
+				exchange.AutoDelete,
+				exchange.Internal,
+				false,
+				exchangeArgs(exchange),
+			)
+		}); err != nil {
 			r.logger.Error("Failed to declare exchange", zap.String("exchange", exchange.Name), zap.Error(err))
+			errs = append(errs, fmt.Errorf("declare exchange %s: %w", exchange.Name, err))
 		} else {
 			r.logger.Info("Declared exchange", zap.String("exchange", exchange.Name))
 		}
 	})
 
-	return nil
+	return errors.Join(errs...)
 }
 
-func (r *RabbitMQService) setupQueues(queues []*models.QueueConfig) error {
+func (r *RabbitMQService) setupQueues(conn *amqp.Connection, queues []*models.QueueConfig) error {
+	var errs []error
+
 	lo.ForEach(queues, func(queue *models.QueueConfig, _ int) {
-		if _, err := r.ch.QueueDeclare(
-			queue.Name,
-			queue.Durable,
-			queue.AutoDelete,
-			false,
-			false,
-			nil,
-		); err != nil {
+		if err := withChannel(conn, func(ch *amqp.Channel) error {
+			_, err := ch.QueueDeclare(
+				queue.Name,
+				queue.Durable,
+				queue.AutoDelete,
+				queue.Exclusive,
+				false,
+				toTable(queue.Args),
+			)
+			return err
+		}); err != nil {
 			r.logger.Error("Failed to declare queue", zap.String("queue", queue.Name), zap.Error(err))
+			errs = append(errs, fmt.Errorf("declare queue %s: %w", queue.Name, err))
 		} else {
 			r.logger.Info("Declared queue", zap.String("queue", queue.Name))
 		}
 	})
 
-	return nil
+	return errors.Join(errs...)
 }
 
-func (r *RabbitMQService) setupBindings(bindings []*models.BindingConfig) error {
+func (r *RabbitMQService) setupBindings(conn *amqp.Connection, bindings []*models.BindingConfig) error {
+	var errs []error
+
 	lo.ForEach(bindings, func(binding *models.BindingConfig, _ int) {
-		if err := r.ch.QueueBind(
-			binding.Queue,
-			binding.RoutingKey,
-			binding.Exchange,
-			false,
-			nil,
-		); err != nil {
+		if binding.DestinationExchange != "" {
+			if err := withChannel(conn, func(ch *amqp.Channel) error {
+				return ch.ExchangeBind(
+					binding.DestinationExchange,
+					binding.RoutingKey,
+					binding.Exchange,
+					false,
+					toTable(binding.Args),
+				)
+			}); err != nil {
+				r.logger.Error("Failed to bind exchange", zap.String("destination", binding.DestinationExchange), zap.String("exchange", binding.Exchange), zap.Error(err))
+				errs = append(errs, fmt.Errorf("bind exchange %s to %s: %w", binding.DestinationExchange, binding.Exchange, err))
+			} else {
+				r.logger.Info("Bound exchange", zap.String("destination", binding.DestinationExchange), zap.String("exchange", binding.Exchange))
+			}
+			return
+		}
+
+		if err := withChannel(conn, func(ch *amqp.Channel) error {
+			return ch.QueueBind(
+				binding.Queue,
+				binding.RoutingKey,
+				binding.Exchange,
+				false,
+				toTable(binding.Args),
+			)
+		}); err != nil {
 			r.logger.Error("Failed to bind queue", zap.String("queue", binding.Queue), zap.String("exchange", binding.Exchange), zap.Error(err))
+			errs = append(errs, fmt.Errorf("bind queue %s to %s: %w", binding.Queue, binding.Exchange, err))
 		} else {
 			r.logger.Info("Bound queue", zap.String("queue", binding.Queue), zap.String("exchange", binding.Exchange))
 		}
 	})
 
-	return nil
+	return errors.Join(errs...)
+}
+
+// withChannel runs fn on a channel of its own, so that a failure closing the channel doesn't affect others.
+func withChannel(conn *amqp.Connection, fn func(ch *amqp.Channel) error) error {
+	ch, err := conn.Channel()
+	if err != nil {
+		return fmt.Errorf("open channel: %w", err)
+	}
+
+	if err := fn(ch); err != nil {
+		// the broker already closed the channel if the error was a channel exception
+		_ = ch.Close()
+		return err
+	}
+
+	return ch.Close()
 }
 
-func (r *RabbitMQService) PublishMessage(exchange, routingKey string, notification *models.Notification) error {
+func (r *RabbitMQService) PublishMessage(ctx context.Context, exchange, routingKey string, notification *models.Notification, opts ...PublishOption) error {
+	options := &publishOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
 	body, err := json.Marshal(notification)
 	if err != nil {
 		return fmt.Errorf("failed to marshal notification: %w", err)
 	}
 
-	if err = r.ch.Publish(
+	ctx, span := startPublishSpan(ctx, exchange, routingKey, notification.ID)
+	defer span.End()
+
+	ch, err := r.channel(ctx, options.failFast)
+	if err != nil {
+		tracing.RecordError(span, err)
+		return fmt.Errorf("failed to publish message: %w", err)
+	}
+
+	if err = ch.PublishWithContext(
+		ctx,
 		exchange,
 		routingKey,
 		false,
 		false,
 		amqp.Publishing{
-			ContentType:  "application/json",
-			Body:         body,
-			DeliveryMode: amqp.Persistent,
-			Timestamp:    time.Now(),
+			Headers:       tracing.Inject(ctx, nil),
+			ContentType:   "application/json",
+			Body:          body,
+			DeliveryMode:  amqp.Persistent,
+			Timestamp:     time.Now(),
+			MessageId:     notification.ID,
+			CorrelationId: options.correlationID,
 		},
 	); err != nil {
+		if errors.Is(err, amqp.ErrClosed) {
+			err = fmt.Errorf("%w: %w", ErrNotConnected, err)
+		}
+		metrics.MessagesPublished.WithLabelValues(exchange, routingKey, publishOutcome(err)).Inc()
+		tracing.RecordError(span, err)
 		return fmt.Errorf("failed to publish message: %w", err)
 	}
 
-	r.logger.Info("Published notification", zap.String("id", notification.ID))
-
-	return nil
-}
-
-func (r *RabbitMQService) ConsumeMessages(queue string, handler func(delivery amqp.Delivery) error) error {
-	msgs, err := r.ch.Consume(
-		queue, "", false, false, false, false, nil,
-	)
-	if err != nil {
-		return fmt.Errorf("consume queue %s: %w", queue, err)
-	}
-
-	forever := make(chan bool)
-
-	go func() {
-		for msg := range msgs {
-			if err := handler(msg); err != nil {
-				r.logger.Error("Failed to process message", zap.Error(err))
-			}
-		}
-	}()
-
-	r.logger.Info("Consumer registered", zap.String("queue", queue))
-
-	<-forever
+	metrics.MessagesPublished.WithLabelValues(exchange, routingKey, metrics.PublishOutcomeSent).Inc()
+	r.logger.Info("Published notification", zap.String("id", notification.ID), zap.String("correlation_id", options.correlationID))
 
 	return nil
 }
 
-func (r *RabbitMQService) GetListQueues() ([]map[string]any, error) {
-	url := fmt.Sprintf("%s/api/queues/%s", r.rabbitMQManagementAddr, r.rabbitMQVHost)
-
-	return r.getAndDecodeAPI(url)
-}
-
-func (r *RabbitMQService) GetListExchanges() ([]map[string]any, error) {
-	url := fmt.Sprintf("%s/api/exchanges/%s", r.rabbitMQManagementAddr, r.rabbitMQVHost)
-
-	return r.getAndDecodeAPI(url)
-}
-
-func (r *RabbitMQService) GetListBindings() ([]map[string]any, error) {
-	url := fmt.Sprintf("%s/api/bindings/%s", r.rabbitMQManagementAddr, r.rabbitMQVHost)
+func (r *RabbitMQService) Close() error {
+	var err error
 
-	return r.getAndDecodeAPI(url)
-}
+	r.closeOnce.Do(func() {
+		close(r.done)
 
-func (r *RabbitMQService) getAndDecodeAPI(url string) ([]map[string]any, error) {
-	req, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("create request: %w", err)
-	}
-	req.SetBasicAuth(r.rabbitMQUsername, r.rabbitMQPassword)
+		r.mu.RLock()
+		conn, ch, pubCh := r.conn, r.ch, r.pubCh
+		r.mu.RUnlock()
 
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("send request: %w", err)
-	}
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			r.logger.Error("failed to close response body", zap.Error(err))
+		if closeErr := ch.Close(); closeErr != nil && !errors.Is(closeErr, amqp.ErrClosed) {
+			err = fmt.Errorf("failed to close channel: %w", closeErr)
 		}
-	}()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %s", resp.Status)
-	}
-
-	var data []map[string]any
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return nil, fmt.Errorf("decode response body: %w", err)
-	}
-
-	return data, nil
-}
+		if closeErr := pubCh.Close(); closeErr != nil && !errors.Is(closeErr, amqp.ErrClosed) && err == nil {
+			err = fmt.Errorf("failed to close publish channel: %w", closeErr)
+		}
+		if closeErr := conn.Close(); closeErr != nil && !errors.Is(closeErr, amqp.ErrClosed) && err == nil {
+			err = fmt.Errorf("failed to close connection: %w", closeErr)
+		}
+	})
 
-func (r *RabbitMQService) Close() error {
-	if err := r.ch.Close(); err != nil {
-		return fmt.Errorf("failed to close channel: %w", err)
-	}
-	if err := r.conn.Close(); err != nil {
-		return fmt.Errorf("failed to close connection: %w", err)
-	}
-	return nil
+	return err
 }
@@ -0,0 +1,446 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"github.com/ngdangkietswe/go-rabbitmq/pkg/management"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type TopologyMode string
+
+const (
+	// TopologyModeDeclare declares the topology on every (re)connect.
+	TopologyModeDeclare TopologyMode = "declare"
+	// TopologyModeVerify never declares anything and only reports how the broker differs from the topology,
+	// for brokers whose definitions are managed elsewhere.
+	TopologyModeVerify TopologyMode = "verify"
+)
+
+// builtinExchanges may be referenced by the topology without being declared in it.
+var builtinExchanges = map[string]bool{
+	"":            true,
+	"amq.direct":  true,
+	"amq.fanout":  true,
+	"amq.topic":   true,
+	"amq.headers": true,
+	"amq.match":   true,
+}
+
+var (
+	exchangeTypes = map[string]bool{"direct": true, "fanout": true, "topic": true, "headers": true}
+	queueTypes    = map[string]bool{"classic": true, "quorum": true, "stream": true}
+	overflowModes = map[string]bool{"drop-head": true, "reject-publish": true, "reject-publish-dlx": true}
+	matchModes    = map[string]bool{"all": true, "any": true, "all-with-x": true, "any-with-x": true}
+	policyTargets = map[string]bool{"queues": true, "exchanges": true, "all": true, "classic_queues": true, "quorum_queues": true, "streams": true}
+	integerArgs   = []string{"x-message-ttl", "x-expires", "x-max-length", "x-max-length-bytes", "x-max-priority", "x-delivery-limit"}
+)
+
+// LoadTopology reads a topology file. The file is YAML, and since JSON is a subset of YAML a JSON file
+// works as well. Unknown fields are rejected so that a typo doesn't silently drop an option.
+func LoadTopology(path string) (*models.Topology, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read topology file: %w", err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	var topology models.Topology
+	if err := decoder.Decode(&topology); err != nil {
+		return nil, fmt.Errorf("decode topology file %s: %w", path, err)
+	}
+
+	return &topology, nil
+}
+
+// ValidateTopology checks a topology before anything is declared and reports every problem found.
+func ValidateTopology(topology *models.Topology) error {
+	var errs []error
+
+	exchanges := make(map[string]bool, len(topology.Exchanges))
+	for i, exchange := range topology.Exchanges {
+		switch {
+		case exchange.Name == "":
+			errs = append(errs, fmt.Errorf("exchanges[%d]: missing name", i))
+			continue
+		case exchanges[exchange.Name]:
+			errs = append(errs, fmt.Errorf("exchange %s: declared twice", exchange.Name))
+		case strings.HasPrefix(exchange.Name, "amq."):
+			errs = append(errs, fmt.Errorf("exchange %s: the amq. prefix is reserved", exchange.Name))
+		}
+		exchanges[exchange.Name] = true
+
+		// x- types come from plugins, e.g. x-delayed-message or x-consistent-hash
+		if !exchangeTypes[exchange.Type] && !strings.HasPrefix(exchange.Type, "x-") {
+			errs = append(errs, fmt.Errorf("exchange %s: unknown type %q", exchange.Name, exchange.Type))
+		}
+	}
+
+	for _, exchange := range topology.Exchanges {
+		if ae := exchange.AlternateExchange; ae != "" && !exchanges[ae] && !builtinExchanges[ae] {
+			errs = append(errs, fmt.Errorf("exchange %s: alternate exchange %s is not declared", exchange.Name, ae))
+		}
+	}
+
+	queues := make(map[string]bool, len(topology.Queues))
+	for i, queue := range topology.Queues {
+		switch {
+		case queue.Name == "":
+			errs = append(errs, fmt.Errorf("queues[%d]: missing name", i))
+			continue
+		case queues[queue.Name]:
+			errs = append(errs, fmt.Errorf("queue %s: declared twice", queue.Name))
+		}
+		queues[queue.Name] = true
+
+		errs = append(errs, validateQueueArgs(queue, exchanges)...)
+	}
+
+	for i, binding := range topology.Bindings {
+		name := fmt.Sprintf("bindings[%d]", i)
+
+		if !exchanges[binding.Exchange] && !builtinExchanges[binding.Exchange] {
+			errs = append(errs, fmt.Errorf("%s: source exchange %q is not declared", name, binding.Exchange))
+		}
+		if binding.Exchange == "" {
+			errs = append(errs, fmt.Errorf("%s: the default exchange can't be bound", name))
+		}
+
+		switch {
+		case (binding.Queue == "") == (binding.DestinationExchange == ""):
+			errs = append(errs, fmt.Errorf("%s: exactly one of queue and destination_exchange must be set", name))
+		case binding.Queue != "" && !queues[binding.Queue]:
+			errs = append(errs, fmt.Errorf("%s: queue %s is not declared", name, binding.Queue))
+		case binding.DestinationExchange != "" && !exchanges[binding.DestinationExchange] && !builtinExchanges[binding.DestinationExchange]:
+			errs = append(errs, fmt.Errorf("%s: destination exchange %s is not declared", name, binding.DestinationExchange))
+		}
+
+		if match, ok := binding.Args["x-match"]; ok {
+			if s, _ := match.(string); !matchModes[s] {
+				errs = append(errs, fmt.Errorf("%s: unknown x-match %v", name, match))
+			}
+		}
+	}
+
+	policies := make(map[string]bool, len(topology.Policies))
+	for i, policy := range topology.Policies {
+		switch {
+		case policy.Name == "":
+			errs = append(errs, fmt.Errorf("policies[%d]: missing name", i))
+			continue
+		case policies[policy.Name]:
+			errs = append(errs, fmt.Errorf("policy %s: declared twice", policy.Name))
+		}
+		policies[policy.Name] = true
+
+		errs = append(errs, validatePolicy(policy, exchanges)...)
+	}
+
+	return errors.Join(errs...)
+}
+
+func validatePolicy(policy *models.PolicyConfig, exchanges map[string]bool) []error {
+	var errs []error
+
+	if _, err := regexp.Compile(policy.Pattern); policy.Pattern == "" || err != nil {
+		errs = append(errs, fmt.Errorf("policy %s: invalid pattern %q", policy.Name, policy.Pattern))
+	}
+	if policy.ApplyTo != "" && !policyTargets[policy.ApplyTo] {
+		errs = append(errs, fmt.Errorf("policy %s: unknown apply_to %q", policy.Name, policy.ApplyTo))
+	}
+	if len(policy.Definition) == 0 {
+		errs = append(errs, fmt.Errorf("policy %s: empty definition", policy.Name))
+	}
+
+	if v, ok := policy.Definition["dead-letter-exchange"]; ok {
+		if dlx, _ := v.(string); !exchanges[dlx] && !builtinExchanges[dlx] {
+			errs = append(errs, fmt.Errorf("policy %s: dead-letter exchange %v is not declared", policy.Name, v))
+		}
+	}
+
+	return errs
+}
+
+// policyApplyTo returns what policy applies to, queues when it isn't set.
+func policyApplyTo(policy *models.PolicyConfig) string {
+	if policy.ApplyTo == "" {
+		return "queues"
+	}
+	return policy.ApplyTo
+}
+
+func validateQueueArgs(queue *models.QueueConfig, exchanges map[string]bool) []error {
+	var errs []error
+
+	if v, ok := queue.Args["x-queue-type"]; ok {
+		queueType, _ := v.(string)
+		switch {
+		case !queueTypes[queueType]:
+			errs = append(errs, fmt.Errorf("queue %s: unknown x-queue-type %v", queue.Name, v))
+		case queueType != "classic" && (!queue.Durable || queue.AutoDelete || queue.Exclusive):
+			errs = append(errs, fmt.Errorf("queue %s: %s queues must be durable, not auto-delete and not exclusive", queue.Name, queueType))
+		}
+	}
+
+	if v, ok := queue.Args["x-overflow"]; ok {
+		if s, _ := v.(string); !overflowModes[s] {
+			errs = append(errs, fmt.Errorf("queue %s: unknown x-overflow %v", queue.Name, v))
+		}
+	}
+
+	if v, ok := queue.Args["x-dead-letter-exchange"]; ok {
+		if dlx, _ := v.(string); !exchanges[dlx] && !builtinExchanges[dlx] {
+			errs = append(errs, fmt.Errorf("queue %s: dead-letter exchange %v is not declared", queue.Name, v))
+		}
+	}
+
+	for _, arg := range integerArgs {
+		v, ok := queue.Args[arg]
+		if !ok {
+			continue
+		}
+		if n, isInt := toInt64(v); !isInt || n < 0 {
+			errs = append(errs, fmt.Errorf("queue %s: %s must be a non-negative integer, got %v", queue.Name, arg, v))
+		}
+	}
+
+	return errs
+}
+
+func toInt64(v any) (int64, bool) {
+	switch n := v.(type) {
+	case int:
+		return int64(n), true
+	case int8:
+		return int64(n), true
+	case int16:
+		return int64(n), true
+	case int32:
+		return int64(n), true
+	case int64:
+		return n, true
+	case uint8:
+		return int64(n), true
+	default:
+		return 0, false
+	}
+}
+
+// toTable converts declare arguments as decoded from the topology file, where nested maps are plain
+// map[string]any, into an amqp.Table the client is able to encode.
+func toTable(args map[string]any) amqp.Table {
+	if args == nil {
+		return nil
+	}
+
+	table := make(amqp.Table, len(args))
+	for k, v := range args {
+		table[k] = toField(v)
+	}
+
+	return table
+}
+
+func toField(v any) any {
+	switch fv := v.(type) {
+	case map[string]any:
+		return toTable(fv)
+	case []any:
+		values := make([]any, len(fv))
+		for i, item := range fv {
+			values[i] = toField(item)
+		}
+		return values
+	case uint:
+		return int64(fv)
+	case uint16:
+		return int32(fv)
+	case uint32:
+		return int64(fv)
+	case uint64:
+		return int64(fv)
+	default:
+		return v
+	}
+}
+
+// exchangeArgs returns the declare arguments of exchange, including its alternate exchange.
+func exchangeArgs(exchange *models.ExchangeConfig) amqp.Table {
+	args := toTable(exchange.Args)
+	if exchange.AlternateExchange != "" {
+		if args == nil {
+			args = amqp.Table{}
+		}
+		args["alternate-exchange"] = exchange.AlternateExchange
+	}
+
+	return args
+}
+
+// VerifyTopology compares the exchanges, queues and bindings on the broker, as reported by the management
+// API, with the desired topology without declaring anything.
+func (r *RabbitMQService) VerifyTopology(ctx context.Context) (*models.TopologyVerification, error) {
+	drifts := make([]models.TopologyDrift, 0)
+
+	for _, exchange := range r.topology.Exchanges {
+		actual, err := r.management.GetExchange(ctx, exchange.Name)
+		if errors.Is(err, management.ErrNotFound) {
+			drifts = append(drifts, models.TopologyDrift{Kind: "exchange", Name: exchange.Name, Field: "missing"})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get exchange %s: %w", exchange.Name, err)
+		}
+
+		drifts = appendDrift(drifts, "exchange", exchange.Name, "type", exchange.Type, actual.Type)
+		drifts = appendDrift(drifts, "exchange", exchange.Name, "durable", exchange.Durable, actual.Durable)
+		drifts = appendDrift(drifts, "exchange", exchange.Name, "auto_delete", exchange.AutoDelete, actual.AutoDelete)
+		drifts = appendDrift(drifts, "exchange", exchange.Name, "internal", exchange.Internal, actual.Internal)
+		drifts = appendArgsDrift(drifts, "exchange", exchange.Name, exchangeArgs(exchange), actual.Arguments)
+	}
+
+	for _, queue := range r.topology.Queues {
+		actual, err := r.management.GetQueue(ctx, queue.Name)
+		if errors.Is(err, management.ErrNotFound) {
+			drifts = append(drifts, models.TopologyDrift{Kind: "queue", Name: queue.Name, Field: "missing"})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get queue %s: %w", queue.Name, err)
+		}
+
+		drifts = appendDrift(drifts, "queue", queue.Name, "durable", queue.Durable, actual.Durable)
+		drifts = appendDrift(drifts, "queue", queue.Name, "auto_delete", queue.AutoDelete, actual.AutoDelete)
+		drifts = appendDrift(drifts, "queue", queue.Name, "exclusive", queue.Exclusive, actual.Exclusive)
+		drifts = appendArgsDrift(drifts, "queue", queue.Name, toTable(queue.Args), actual.Arguments)
+	}
+
+	for _, binding := range r.topology.Bindings {
+		destination := binding.Queue
+		if binding.DestinationExchange != "" {
+			destination = binding.DestinationExchange
+		}
+		name := fmt.Sprintf("%s -> %s (%s)", binding.Exchange, destination, binding.RoutingKey)
+
+		actual, err := r.management.ListBindingsBetween(ctx, binding.Exchange, destination, binding.DestinationExchange != "")
+		if err != nil && !errors.Is(err, management.ErrNotFound) {
+			return nil, fmt.Errorf("get binding %s: %w", name, err)
+		}
+
+		desiredArgs := normalizeArgs(toTable(binding.Args))
+		found := false
+		for _, b := range actual {
+			if b.RoutingKey == binding.RoutingKey && reflect.DeepEqual(desiredArgs, normalizeArgs(b.Arguments)) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			drifts = append(drifts, models.TopologyDrift{Kind: "binding", Name: name, Field: "missing", Desired: desiredArgs})
+		}
+	}
+
+	for _, policy := range r.topology.Policies {
+		actual, err := r.management.GetPolicy(ctx, policy.Name)
+		if errors.Is(err, management.ErrNotFound) {
+			drifts = append(drifts, models.TopologyDrift{Kind: "policy", Name: policy.Name, Field: "missing"})
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get policy %s: %w", policy.Name, err)
+		}
+
+		drifts = appendDrift(drifts, "policy", policy.Name, "pattern", policy.Pattern, actual.Pattern)
+		drifts = appendDrift(drifts, "policy", policy.Name, "apply_to", policyApplyTo(policy), actual.ApplyTo)
+		drifts = appendDrift(drifts, "policy", policy.Name, "priority", policy.Priority, actual.Priority)
+		drifts = appendDrift(drifts, "policy", policy.Name, "definition", normalizeArgs(policy.Definition), normalizeArgs(actual.Definition))
+	}
+
+	return &models.TopologyVerification{
+		InSync: len(drifts) == 0,
+		Drifts: drifts,
+	}, nil
+}
+
+// reportDrift logs how the broker differs from the topology. It is used at startup in verify mode.
+func (r *RabbitMQService) reportDrift() {
+	verification, err := r.VerifyTopology(context.Background())
+	if err != nil {
+		r.logger.Error("Failed to verify topology", zap.Error(err))
+		return
+	}
+
+	if verification.InSync {
+		r.logger.Info("Topology verified, broker is in sync")
+		return
+	}
+
+	for _, drift := range verification.Drifts {
+		r.logger.Warn("Topology drift", zap.String("kind", drift.Kind), zap.String("name", drift.Name), zap.String("field", drift.Field), zap.Any("desired", drift.Desired), zap.Any("actual", drift.Actual))
+	}
+}
+
+func appendDrift(drifts []models.TopologyDrift, kind, name, field string, desired, actual any) []models.TopologyDrift {
+	if reflect.DeepEqual(desired, actual) {
+		return drifts
+	}
+
+	return append(drifts, models.TopologyDrift{Kind: kind, Name: name, Field: field, Desired: desired, Actual: actual})
+}
+
+func appendArgsDrift(drifts []models.TopologyDrift, kind, name string, desired amqp.Table, actual map[string]any) []models.TopologyDrift {
+	desiredArgs, actualArgs := normalizeArgs(desired), normalizeArgs(actual)
+
+	keys := make([]string, 0, len(desiredArgs)+len(actualArgs))
+	for k := range desiredArgs {
+		keys = append(keys, k)
+	}
+	for k := range actualArgs {
+		if _, ok := desiredArgs[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		drifts = appendDrift(drifts, kind, name, "arguments."+k, desiredArgs[k], actualArgs[k])
+	}
+
+	return drifts
+}
+
+// normalizeArgs round-trips arguments through JSON so that declared values (e.g. int TTLs) compare equal to
+// the ones decoded from the management API (float64).
+func normalizeArgs(args map[string]any) map[string]any {
+	normalized := make(map[string]any)
+	if len(args) == 0 {
+		return normalized
+	}
+
+	data, err := json.Marshal(args)
+	if err != nil {
+		return normalized
+	}
+	_ = json.Unmarshal(data, &normalized)
+
+	return normalized
+}
@@ -0,0 +1,127 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package services
+
+import (
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestValidateTopology(t *testing.T) {
+	// valid returns a small valid topology that the cases break one way each
+	valid := func() *models.Topology {
+		return &models.Topology{
+			Exchanges: []*models.ExchangeConfig{
+				{Name: "exchange_notification", Type: "direct", Durable: true},
+				{Name: "exchange_dead_letter", Type: "fanout", Durable: true},
+			},
+			Queues: []*models.QueueConfig{
+				{Name: "queue_notification", Durable: true, Args: map[string]any{"x-queue-type": "quorum", "x-max-length": 1000}},
+				{Name: "queue_dead_letter", Durable: true},
+			},
+			Bindings: []*models.BindingConfig{
+				{Exchange: "exchange_notification", Queue: "queue_notification", RoutingKey: "notification"},
+				{Exchange: "exchange_dead_letter", Queue: "queue_dead_letter"},
+			},
+			Policies: []*models.PolicyConfig{
+				{Name: "dead-letter", Pattern: "^queue_notification$", Definition: map[string]any{"dead-letter-exchange": "exchange_dead_letter"}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*models.Topology)
+		wantErr string
+	}{
+		{name: "valid", modify: func(*models.Topology) {}},
+		{name: "missing exchange name", modify: func(tp *models.Topology) { tp.Exchanges[0].Name = "" }, wantErr: "exchanges[0]: missing name"},
+		{name: "duplicate exchange", modify: func(tp *models.Topology) { tp.Exchanges[1].Name = "exchange_notification" }, wantErr: "declared twice"},
+		{name: "reserved prefix", modify: func(tp *models.Topology) { tp.Exchanges[0].Name = "amq.mine" }, wantErr: "the amq. prefix is reserved"},
+		{name: "unknown exchange type", modify: func(tp *models.Topology) { tp.Exchanges[0].Type = "round-robin" }, wantErr: "unknown type"},
+		{name: "plugin exchange type", modify: func(tp *models.Topology) { tp.Exchanges[0].Type = "x-delayed-message" }},
+		{name: "undeclared alternate exchange", modify: func(tp *models.Topology) { tp.Exchanges[0].AlternateExchange = "exchange_unrouted" }, wantErr: "alternate exchange exchange_unrouted is not declared"},
+		{name: "duplicate queue", modify: func(tp *models.Topology) { tp.Queues[1].Name = "queue_notification" }, wantErr: "queue queue_notification: declared twice"},
+		{name: "unknown queue type", modify: func(tp *models.Topology) { tp.Queues[0].Args["x-queue-type"] = "lazy" }, wantErr: "unknown x-queue-type"},
+		{name: "transient quorum queue", modify: func(tp *models.Topology) { tp.Queues[0].Durable = false }, wantErr: "quorum queues must be durable"},
+		{name: "unknown overflow", modify: func(tp *models.Topology) { tp.Queues[0].Args["x-overflow"] = "drop-tail" }, wantErr: "unknown x-overflow"},
+		{name: "negative max length", modify: func(tp *models.Topology) { tp.Queues[0].Args["x-max-length"] = -1 }, wantErr: "x-max-length must be a non-negative integer"},
+		{name: "string TTL", modify: func(tp *models.Topology) { tp.Queues[0].Args["x-message-ttl"] = "60000" }, wantErr: "x-message-ttl must be a non-negative integer"},
+		{name: "undeclared queue dead-letter exchange", modify: func(tp *models.Topology) { tp.Queues[0].Args["x-dead-letter-exchange"] = "exchange_missing" }, wantErr: "dead-letter exchange exchange_missing is not declared"},
+		{name: "undeclared source exchange", modify: func(tp *models.Topology) { tp.Bindings[0].Exchange = "exchange_missing" }, wantErr: `source exchange "exchange_missing" is not declared`},
+		{name: "default exchange bound", modify: func(tp *models.Topology) { tp.Bindings[0].Exchange = "" }, wantErr: "the default exchange can't be bound"},
+		{name: "queue and destination exchange", modify: func(tp *models.Topology) { tp.Bindings[0].DestinationExchange = "exchange_dead_letter" }, wantErr: "exactly one of queue and destination_exchange"},
+		{name: "undeclared queue", modify: func(tp *models.Topology) { tp.Bindings[0].Queue = "queue_missing" }, wantErr: "queue queue_missing is not declared"},
+		{name: "exchange to exchange", modify: func(tp *models.Topology) {
+			tp.Bindings[0].Queue, tp.Bindings[0].DestinationExchange = "", "exchange_dead_letter"
+		}},
+		{name: "unknown x-match", modify: func(tp *models.Topology) { tp.Bindings[0].Args = map[string]any{"x-match": "some"} }, wantErr: "unknown x-match"},
+		{name: "duplicate policy", modify: func(tp *models.Topology) {
+			tp.Policies = append(tp.Policies, &models.PolicyConfig{Name: "dead-letter", Pattern: ".*", Definition: map[string]any{"max-length": 10}})
+		}, wantErr: "policy dead-letter: declared twice"},
+		{name: "invalid policy pattern", modify: func(tp *models.Topology) { tp.Policies[0].Pattern = "^queue_(" }, wantErr: "invalid pattern"},
+		{name: "unknown policy target", modify: func(tp *models.Topology) { tp.Policies[0].ApplyTo = "bindings" }, wantErr: `unknown apply_to "bindings"`},
+		{name: "empty policy", modify: func(tp *models.Topology) { tp.Policies[0].Definition = nil }, wantErr: "empty definition"},
+		{name: "undeclared policy dead-letter exchange", modify: func(tp *models.Topology) {
+			tp.Policies[0].Definition["dead-letter-exchange"] = "exchange_missing"
+		}, wantErr: "policy dead-letter: dead-letter exchange exchange_missing is not declared"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			topology := valid()
+			tt.modify(topology)
+
+			err := ValidateTopology(topology)
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("ValidateTopology error = %v, want none", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Errorf("ValidateTopology error = %v, want one containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateTopologyReportsEveryProblem(t *testing.T) {
+	err := ValidateTopology(&models.Topology{
+		Exchanges: []*models.ExchangeConfig{{Name: "amq.mine", Type: "direct"}},
+		Queues:    []*models.QueueConfig{{Name: "q", Args: map[string]any{"x-overflow": "drop-tail"}}},
+	})
+	if err == nil {
+		t.Fatal("ValidateTopology accepted an invalid topology")
+	}
+
+	for _, want := range []string{"amq. prefix", "x-overflow"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("ValidateTopology error %q doesn't report %q", err, want)
+		}
+	}
+}
+
+func TestShippedTopologyIsValid(t *testing.T) {
+	topology, err := LoadTopology("../../configs/topology.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ValidateTopology(topology); err != nil {
+		t.Errorf("configs/topology.yaml: %v", err)
+	}
+}
+
+func TestLoadTopologyRejectsUnknownFields(t *testing.T) {
+	path := t.TempDir() + "/topology.yaml"
+	if err := os.WriteFile(path, []byte("exchanges:\n  - name: e\n    type: direct\n    durabel: true\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := LoadTopology(path); err == nil || !strings.Contains(err.Error(), "durabel") {
+		t.Errorf("LoadTopology error = %v, want one naming the unknown field", err)
+	}
+}
@@ -0,0 +1,315 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package services
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/config"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+	htmltemplate "html/template"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	texttemplate "text/template"
+)
+
+var (
+	// ErrTemplateNotFound is returned for a template ID, or a locale of it, that doesn't exist.
+	ErrTemplateNotFound = errors.New("template not found")
+	// ErrInvalidTemplate is returned for a template whose ID, locale or content is invalid.
+	ErrInvalidTemplate = errors.New("invalid template")
+	// ErrRenderTemplate is returned when a template can't be rendered with the given variables, e.g. because
+	// one is missing.
+	ErrRenderTemplate = errors.New("failed to render template")
+)
+
+var (
+	templateIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+	localePattern     = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+)
+
+// TemplateService holds the message templates, by ID and locale, and renders them. Templates are the YAML
+// files <TEMPLATE_DIR>/<id>/<locale>.yaml, shipped with the image and read at startup; they are changed by
+// changing the files and restarting, not through the API, so that every replica serves the same templates.
+type TemplateService struct {
+	dir           string
+	defaultLocale string
+	logger        *zap.Logger
+
+	templates map[string]map[string]*models.TemplateContent // by ID, then locale
+}
+
+func NewTemplateService(logger *zap.Logger) (*TemplateService, error) {
+	s := &TemplateService{
+		dir:           config.GetString("TEMPLATE_DIR", "./configs/templates"),
+		defaultLocale: NormalizeLocale(config.GetString("TEMPLATE_DEFAULT_LOCALE", "en")),
+		logger:        logger,
+		templates:     make(map[string]map[string]*models.TemplateContent),
+	}
+
+	if !localePattern.MatchString(s.defaultLocale) {
+		return nil, fmt.Errorf("invalid TEMPLATE_DEFAULT_LOCALE %q", s.defaultLocale)
+	}
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// NormalizeLocale returns locale in the form templates are stored under, e.g. pt-br for pt_BR.
+func NormalizeLocale(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+func (s *TemplateService) load() error {
+	files, err := filepath.Glob(filepath.Join(s.dir, "*", "*.yaml"))
+	if err != nil {
+		return fmt.Errorf("list templates: %w", err)
+	}
+
+	for _, file := range files {
+		id := filepath.Base(filepath.Dir(file))
+		locale := strings.TrimSuffix(filepath.Base(file), ".yaml")
+
+		if err := validateTemplateName(id, locale); err != nil {
+			return fmt.Errorf("template file %s: %w", file, err)
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return fmt.Errorf("read template file: %w", err)
+		}
+
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+
+		var content models.TemplateContent
+		if err := decoder.Decode(&content); err != nil {
+			return fmt.Errorf("decode template file %s: %w", file, err)
+		}
+
+		if err := validateTemplateContent(&content); err != nil {
+			return fmt.Errorf("template file %s: %w", file, err)
+		}
+
+		if s.templates[id] == nil {
+			s.templates[id] = make(map[string]*models.TemplateContent)
+		}
+		s.templates[id][locale] = &content
+	}
+
+	s.logger.Info("Templates loaded", zap.String("dir", s.dir), zap.Int("files", len(files)))
+
+	return nil
+}
+
+// List returns the ID and locales of every template, sorted by ID.
+func (s *TemplateService) List() []*models.TemplateSummary {
+	summaries := make([]*models.TemplateSummary, 0, len(s.templates))
+	for id, locales := range s.templates {
+		summary := &models.TemplateSummary{ID: id}
+		for locale := range locales {
+			summary.Locales = append(summary.Locales, locale)
+		}
+		sort.Strings(summary.Locales)
+		summaries = append(summaries, summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].ID < summaries[j].ID
+	})
+
+	return summaries
+}
+
+// Get returns every locale of the template id.
+func (s *TemplateService) Get(id string) (*models.TemplateResponse, error) {
+	locales, ok := s.templates[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTemplateNotFound, id)
+	}
+
+	response := &models.TemplateResponse{
+		ID:      id,
+		Locales: make(map[string]*models.TemplateContent, len(locales)),
+	}
+	for locale, content := range locales {
+		response.Locales[locale] = content
+	}
+
+	return response, nil
+}
+
+// Render renders the template id for channel with data. The locale falls back from the requested one to its
+// parent locales, e.g. from pt-br to pt, and then to TEMPLATE_DEFAULT_LOCALE. The title, text and HTML of
+// the channel's variant replace the default ones; the HTML is only rendered for email.
+func (s *TemplateService) Render(id, locale string, channel models.NotificationType, data map[string]interface{}) (*models.RenderedTemplate, error) {
+	resolved, content, err := s.resolve(id, locale)
+	if err != nil {
+		return nil, err
+	}
+
+	title, text, html := content.Title, content.Text, content.HTML
+	if variant := content.Channels[channel]; variant != nil {
+		if variant.Title != "" {
+			title = variant.Title
+		}
+		if variant.Text != "" {
+			text = variant.Text
+		}
+		if variant.HTML != "" {
+			html = variant.HTML
+		}
+	}
+
+	rendered := &models.RenderedTemplate{
+		TemplateID: id,
+		Locale:     resolved,
+	}
+
+	if rendered.Title, err = renderText("title", title, data); err != nil {
+		return nil, err
+	}
+	if rendered.Message, err = renderText("text", text, data); err != nil {
+		return nil, err
+	}
+	if channel == models.NotificationTypeEmail && html != "" {
+		if rendered.HTML, err = renderHTML(html, data); err != nil {
+			return nil, err
+		}
+	}
+
+	return rendered, nil
+}
+
+// TemplateData returns the data a template is rendered with: the notification metadata, with the variables
+// taking precedence.
+func TemplateData(metaData, variables map[string]interface{}) map[string]interface{} {
+	data := make(map[string]interface{}, len(metaData)+len(variables))
+	for k, v := range metaData {
+		data[k] = v
+	}
+	for k, v := range variables {
+		data[k] = v
+	}
+	return data
+}
+
+func (s *TemplateService) resolve(id, locale string) (string, *models.TemplateContent, error) {
+	locales, ok := s.templates[id]
+	if !ok {
+		return "", nil, fmt.Errorf("%w: %s", ErrTemplateNotFound, id)
+	}
+
+	candidates := fallbackLocales(NormalizeLocale(locale), s.defaultLocale)
+	for _, candidate := range candidates {
+		if content, ok := locales[candidate]; ok {
+			return candidate, content, nil
+		}
+	}
+
+	return "", nil, fmt.Errorf("%w: %s in any of the locales %v", ErrTemplateNotFound, id, candidates)
+}
+
+// fallbackLocales returns locale, its parent locales and then those of defaultLocale, e.g. zh-hant-tw,
+// zh-hant, zh, en.
+func fallbackLocales(locale, defaultLocale string) []string {
+	var locales []string
+	seen := make(map[string]bool)
+
+	for _, l := range []string{locale, defaultLocale} {
+		for l != "" {
+			if !seen[l] {
+				seen[l] = true
+				locales = append(locales, l)
+			}
+			i := strings.LastIndex(l, "-")
+			if i < 0 {
+				break
+			}
+			l = l[:i]
+		}
+	}
+
+	return locales
+}
+
+func validateTemplateName(id, locale string) error {
+	if !templateIDPattern.MatchString(id) {
+		return fmt.Errorf("%w: ID must be lowercase letters, digits, - and _, at most 64 characters", ErrInvalidTemplate)
+	}
+	if !localePattern.MatchString(locale) {
+		return fmt.Errorf("%w: locale %q is not a language tag such as en or pt-br", ErrInvalidTemplate, locale)
+	}
+	return nil
+}
+
+// validateTemplateContent checks that the text of the template is set and that every field parses.
+func validateTemplateContent(content *models.TemplateContent) error {
+	if strings.TrimSpace(content.Text) == "" {
+		return fmt.Errorf("%w: text is required", ErrInvalidTemplate)
+	}
+
+	variants := map[string]*models.TemplateVariant{
+		"": {Title: content.Title, Text: content.Text, HTML: content.HTML},
+	}
+	for channel, variant := range content.Channels {
+		if variant == nil {
+			return fmt.Errorf("%w: empty variant for channel %s", ErrInvalidTemplate, channel)
+		}
+		variants[string(channel)+"."] = variant
+	}
+
+	for prefix, variant := range variants {
+		if _, err := texttemplate.New(prefix + "title").Parse(variant.Title); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+		}
+		if _, err := texttemplate.New(prefix + "text").Parse(variant.Text); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+		}
+		if _, err := htmltemplate.New(prefix + "html").Parse(variant.HTML); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+		}
+	}
+
+	return nil
+}
+
+func renderText(name, text string, data map[string]interface{}) (string, error) {
+	tmpl, err := texttemplate.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrRenderTemplate, err)
+	}
+
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrRenderTemplate, err)
+	}
+
+	return buf.String(), nil
+}
+
+func renderHTML(html string, data map[string]interface{}) (string, error) {
+	tmpl, err := htmltemplate.New("html").Option("missingkey=error").Parse(html)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrRenderTemplate, err)
+	}
+
+	var buf strings.Builder
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrRenderTemplate, err)
+	}
+
+	return buf.String(), nil
+}
@@ -0,0 +1,137 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package services
+
+import (
+	"errors"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/models"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestFallbackLocales(t *testing.T) {
+	tests := []struct {
+		locale        string
+		defaultLocale string
+		want          []string
+	}{
+		{locale: "pt-br", defaultLocale: "en", want: []string{"pt-br", "pt", "en"}},
+		{locale: "zh-hant-tw", defaultLocale: "en", want: []string{"zh-hant-tw", "zh-hant", "zh", "en"}},
+		{locale: "en-gb", defaultLocale: "en", want: []string{"en-gb", "en"}},
+		{locale: "en", defaultLocale: "en", want: []string{"en"}},
+		{locale: "vi", defaultLocale: "en-us", want: []string{"vi", "en-us", "en"}},
+		{locale: "", defaultLocale: "en", want: []string{"en"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.locale, func(t *testing.T) {
+			if got := fallbackLocales(tt.locale, tt.defaultLocale); !slices.Equal(got, tt.want) {
+				t.Errorf("fallbackLocales(%q, %q) = %v, want %v", tt.locale, tt.defaultLocale, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateServiceRender(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, "welcome", "en", "title: Welcome {{.name}}\ntext: Hi {{.name}}\nhtml: <p>Hi {{.name}}</p>\nchannels:\n  sms:\n    text: Hi {{.name}}!\n")
+	writeTemplate(t, dir, "welcome", "pt", "title: Bem-vindo {{.name}}\ntext: Olá {{.name}}\n")
+	writeTemplate(t, dir, "welcome", "pt-br", "title: Bem-vindo {{.name}}\ntext: Oi {{.name}}\n")
+
+	viper.Set("TEMPLATE_DIR", dir)
+	viper.Set("TEMPLATE_DEFAULT_LOCALE", "en")
+	t.Cleanup(viper.Reset)
+
+	s, err := NewTemplateService(zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := map[string]interface{}{"name": "<Kiet>"}
+
+	tests := []struct {
+		name        string
+		id          string
+		locale      string
+		channel     models.NotificationType
+		data        map[string]interface{}
+		wantLocale  string
+		wantMessage string
+		wantHTML    string
+		wantErr     error
+	}{
+		{name: "exact locale", id: "welcome", locale: "pt-br", channel: models.NotificationTypePush, data: data, wantLocale: "pt-br", wantMessage: "Oi <Kiet>"},
+		{name: "normalized locale", id: "welcome", locale: "pt_BR", channel: models.NotificationTypePush, data: data, wantLocale: "pt-br", wantMessage: "Oi <Kiet>"},
+		{name: "parent locale", id: "welcome", locale: "pt-pt", channel: models.NotificationTypePush, data: data, wantLocale: "pt", wantMessage: "Olá <Kiet>"},
+		{name: "default locale", id: "welcome", locale: "vi", channel: models.NotificationTypePush, data: data, wantLocale: "en", wantMessage: "Hi <Kiet>"},
+		{name: "no locale", id: "welcome", channel: models.NotificationTypePush, data: data, wantLocale: "en", wantMessage: "Hi <Kiet>"},
+		{name: "channel variant", id: "welcome", locale: "en", channel: models.NotificationTypeSMS, data: data, wantLocale: "en", wantMessage: "Hi <Kiet>!"},
+		{name: "escaped HTML for email", id: "welcome", locale: "en", channel: models.NotificationTypeEmail, data: data, wantLocale: "en", wantMessage: "Hi <Kiet>", wantHTML: "<p>Hi &lt;Kiet&gt;</p>"},
+		{name: "missing variable", id: "welcome", locale: "en", channel: models.NotificationTypePush, data: map[string]interface{}{}, wantErr: ErrRenderTemplate},
+		{name: "unknown template", id: "goodbye", locale: "en", channel: models.NotificationTypePush, data: data, wantErr: ErrTemplateNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rendered, err := s.Render(tt.id, tt.locale, tt.channel, tt.data)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("Render error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Render: %v", err)
+			}
+
+			if rendered.Locale != tt.wantLocale || rendered.Message != tt.wantMessage || rendered.HTML != tt.wantHTML {
+				t.Errorf("Render = %+v, want locale %q, message %q and HTML %q", rendered, tt.wantLocale, tt.wantMessage, tt.wantHTML)
+			}
+		})
+	}
+}
+
+func TestTemplateServiceWithoutDefaultLocale(t *testing.T) {
+	dir := t.TempDir()
+	writeTemplate(t, dir, "alert", "vi", "text: Cảnh báo\n")
+
+	viper.Set("TEMPLATE_DIR", dir)
+	viper.Set("TEMPLATE_DEFAULT_LOCALE", "en")
+	t.Cleanup(viper.Reset)
+
+	s, err := NewTemplateService(zap.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Render("alert", "fr", models.NotificationTypePush, nil); !errors.Is(err, ErrTemplateNotFound) {
+		t.Errorf("Render error = %v, want %v", err, ErrTemplateNotFound)
+	}
+}
+
+func TestShippedTemplatesLoad(t *testing.T) {
+	viper.Set("TEMPLATE_DIR", "../../configs/templates")
+	t.Cleanup(viper.Reset)
+
+	if _, err := NewTemplateService(zap.NewNop()); err != nil {
+		t.Errorf("configs/templates: %v", err)
+	}
+}
+
+func writeTemplate(t *testing.T, dir, id, locale, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Join(dir, id), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, id, locale+".yaml"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,147 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+// Package tracing sets up OpenTelemetry and propagates W3C trace context through AMQP message headers, so
+// that a notification can be followed from the API request that accepted it to the worker that sent it.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"github.com/ngdangkietswe/go-rabbitmq/internal/config"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/ngdangkietswe/go-rabbitmq"
+
+// Exporter is where spans are sent, set with TRACING_EXPORTER.
+type Exporter string
+
+const (
+	ExporterOTLP   Exporter = "otlp"
+	ExporterStdout Exporter = "stdout"
+	ExporterNone   Exporter = "none"
+)
+
+// Setup installs the global tracer provider and propagator for serviceName. The exporter is chosen with
+// TRACING_EXPORTER: otlp sends spans over OTLP/HTTP to TRACING_OTLP_ENDPOINT, stdout prints them, and none,
+// the default, records nothing while still propagating incoming trace context. The returned function
+// flushes and stops the exporter.
+func Setup(ctx context.Context, serviceName string) (func(context.Context) error, error) {
+	// propagate even when disabled, so that a trace started upstream isn't cut at this service
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+
+	switch kind := Exporter(config.GetString("TRACING_EXPORTER", string(ExporterNone))); kind {
+	case ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		var err error
+		if exporter, err = stdouttrace.New(); err != nil {
+			return nil, fmt.Errorf("create stdout exporter: %w", err)
+		}
+	case ExporterOTLP:
+		opts := []otlptracehttp.Option{
+			otlptracehttp.WithEndpoint(config.GetString("TRACING_OTLP_ENDPOINT", "localhost:4318")),
+		}
+		if config.GetBool("TRACING_OTLP_INSECURE", true) {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+
+		var err error
+		if exporter, err = otlptracehttp.New(ctx, opts...); err != nil {
+			return nil, fmt.Errorf("create otlp exporter: %w", err)
+		}
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter %q, expected %q, %q or %q", kind, ExporterOTLP, ExporterStdout, ExporterNone)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceName(serviceName)))
+	if err != nil {
+		return nil, fmt.Errorf("create resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer of this module from the global provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Inject returns a copy of headers with the trace context of ctx added, replacing any trace context the
+// headers already carried. headers itself is left untouched and may be nil.
+func Inject(ctx context.Context, headers amqp.Table) amqp.Table {
+	propagator := otel.GetTextMapPropagator()
+
+	injected := make(amqp.Table, len(headers)+len(propagator.Fields()))
+	for k, v := range headers {
+		injected[k] = v
+	}
+	for _, field := range propagator.Fields() {
+		delete(injected, field)
+	}
+
+	propagator.Inject(ctx, HeaderCarrier(injected))
+
+	return injected
+}
+
+// Extract returns ctx with the trace context found in headers, if any.
+func Extract(ctx context.Context, headers amqp.Table) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, HeaderCarrier(headers))
+}
+
+// RecordError marks span as failed with err, if err is not nil.
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// HeaderCarrier adapts AMQP message headers to a propagation.TextMapCarrier.
+type HeaderCarrier amqp.Table
+
+func (c HeaderCarrier) Get(key string) string {
+	switch v := c[key].(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	default:
+		return ""
+	}
+}
+
+func (c HeaderCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+func (c HeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
@@ -16,20 +16,57 @@ const (
 type Queue string
 
 const (
-	QueueNotification Queue = "queue_notification"
-	QueueLog          Queue = "queue_log"
+	QueueNotification           Queue = "queue_notification"
+	QueueNotificationDeadLetter Queue = "queue_notification_dlq"
+	QueueNotificationParkingLot Queue = "queue_notification_parking_lot"
+	QueueLog                    Queue = "queue_log"
+	QueuePushTokenInvalidated   Queue = "queue_push_token_invalidated"
+	QueueInboxPrefix            Queue = "queue_inbox." // followed by the recipient
 )
 
 type Exchange string
 
 const (
-	ExchangeNotification Exchange = "exchange_notification"
-	ExchangeLog          Exchange = "exchange_log"
+	ExchangeNotification      Exchange = "exchange_notification"
+	ExchangeDeadLetter        Exchange = "exchange_dead_letter"
+	ExchangeNotificationRetry Exchange = "exchange_notification_retry"
+	ExchangeLog               Exchange = "exchange_log"
+	ExchangePushEvent         Exchange = "exchange_push_event"
 )
 
 type RoutingKey string
 
 const (
-	RoutingKeyNotification RoutingKey = "notification.created"
-	RoutingKeyLog          RoutingKey = "log.created"
+	RoutingKeyNotification         RoutingKey = "notification.created"
+	RoutingKeyLog                  RoutingKey = "log.created"
+	RoutingKeyPushTokenInvalidated RoutingKey = "push.token.invalidated"
+)
+
+type Header string
+
+const (
+	HeaderRetryAttempt Header = "x-retry-attempt"
+	HeaderLastError    Header = "x-last-error"
+	HeaderDeath        Header = "x-death"
+	HeaderReplayedBy   Header = "x-replayed-by"
+	HeaderReplayedAt   Header = "x-replayed-at"
+	HeaderMovedFrom    Header = "x-moved-from"
+	HeaderMovedBy      Header = "x-moved-by"
+	HeaderMovedAt      Header = "x-moved-at"
+	HeaderMoveJob      Header = "x-move-job"
+	HeaderPublishID    Header = "x-publish-id"
+)
+
+// MetaDataKey is a key of the notification metadata read by the providers.
+type MetaDataKey string
+
+const (
+	MetaDataHTML        MetaDataKey = "html"
+	MetaDataHeaders     MetaDataKey = "headers"
+	MetaDataAttachments MetaDataKey = "attachments"
+	MetaDataPlatform    MetaDataKey = "platform"
+	MetaDataBadge       MetaDataKey = "badge"
+	MetaDataSound       MetaDataKey = "sound"
+	MetaDataPriority    MetaDataKey = "priority"
+	MetaDataBlocks      MetaDataKey = "blocks"
 )
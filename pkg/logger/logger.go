@@ -6,6 +6,7 @@
 package logger
 
 import (
+	"context"
 	"github.com/ngdangkietswe/go-rabbitmq/pkg/constants"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -37,3 +38,19 @@ func NewLoggerDevelopment() (*zap.Logger, error) {
 	loggerCfg.EncoderConfig.EncodeLevel = zapcore.CapitalColorLevelEncoder
 	return loggerCfg.Build()
 }
+
+type contextKey struct{}
+
+// NewContext returns ctx carrying logger, typically a child logger with fields identifying the request or
+// message being handled.
+func NewContext(ctx context.Context, logger *zap.Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, logger)
+}
+
+// FromContext returns the logger carried by ctx, or fallback when there is none.
+func FromContext(ctx context.Context, fallback *zap.Logger) *zap.Logger {
+	if logger, ok := ctx.Value(contextKey{}).(*zap.Logger); ok {
+		return logger
+	}
+	return fallback
+}
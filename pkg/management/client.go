@@ -0,0 +1,444 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package management
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned when the requested object doesn't exist.
+var ErrNotFound = errors.New("rabbitmq management: not found")
+
+// StatusError is returned for any other unexpected response status.
+type StatusError struct {
+	StatusCode int
+	Status     string
+	Reason     string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("rabbitmq management: unexpected status %s: %s", e.Status, e.Reason)
+}
+
+type Config struct {
+	Address  string // e.g. http://localhost:15672
+	VHost    string // URL-escaped, e.g. %2F for the default vhost
+	Username string
+	Password string
+
+	Timeout      time.Duration // Per attempt, defaults to 10s
+	MaxRetries   int           // Retries of GET requests after network errors and 5xx responses
+	RetryBackoff time.Duration // Delay before the first retry, doubled for each following one
+
+	TLSCAFile             string // PEM bundle to verify the server certificate with, instead of the system pool
+	TLSInsecureSkipVerify bool
+}
+
+type Client struct {
+	config     Config
+	httpClient *http.Client
+}
+
+func NewClient(config Config) (*Client, error) {
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+	if config.RetryBackoff <= 0 {
+		config.RetryBackoff = 200 * time.Millisecond
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if config.TLSCAFile != "" || config.TLSInsecureSkipVerify {
+		tlsConfig := &tls.Config{
+			InsecureSkipVerify: config.TLSInsecureSkipVerify,
+		}
+
+		if config.TLSCAFile != "" {
+			pem, err := os.ReadFile(config.TLSCAFile)
+			if err != nil {
+				return nil, fmt.Errorf("read CA file: %w", err)
+			}
+
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no certificate found in CA file %s", config.TLSCAFile)
+			}
+			tlsConfig.RootCAs = pool
+		}
+
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return &Client{
+		config: config,
+		httpClient: &http.Client{
+			Timeout:   config.Timeout,
+			Transport: transport,
+		},
+	}, nil
+}
+
+// Ping checks that the API is reachable and accepts the credentials.
+func (c *Client) Ping(ctx context.Context) error {
+	var whoami struct {
+		Name string `json:"name"`
+	}
+	return c.get(ctx, "/api/whoami", nil, &whoami)
+}
+
+func (c *Client) ListQueues(ctx context.Context, params *ListParams) (*Page[Queue], error) {
+	return list[Queue](ctx, c, "/api/queues/"+c.config.VHost, params)
+}
+
+func (c *Client) GetQueue(ctx context.Context, name string) (*Queue, error) {
+	var queue Queue
+	if err := c.get(ctx, fmt.Sprintf("/api/queues/%s/%s", c.config.VHost, url.PathEscape(name)), nil, &queue); err != nil {
+		return nil, err
+	}
+
+	return &queue, nil
+}
+
+func (c *Client) ListExchanges(ctx context.Context, params *ListParams) (*Page[Exchange], error) {
+	return list[Exchange](ctx, c, "/api/exchanges/"+c.config.VHost, params)
+}
+
+func (c *Client) GetExchange(ctx context.Context, name string) (*Exchange, error) {
+	var exchange Exchange
+	if err := c.get(ctx, fmt.Sprintf("/api/exchanges/%s/%s", c.config.VHost, url.PathEscape(name)), nil, &exchange); err != nil {
+		return nil, err
+	}
+
+	return &exchange, nil
+}
+
+// ListBindings returns every binding of the vhost. The endpoint doesn't support pagination.
+func (c *Client) ListBindings(ctx context.Context) ([]Binding, error) {
+	var bindings []Binding
+	if err := c.get(ctx, "/api/bindings/"+c.config.VHost, nil, &bindings); err != nil {
+		return nil, err
+	}
+
+	return bindings, nil
+}
+
+// ListBindingsBetween returns the bindings from the source exchange to a destination queue, or to a
+// destination exchange when toExchange is set.
+func (c *Client) ListBindingsBetween(ctx context.Context, source, destination string, toExchange bool) ([]Binding, error) {
+	var bindings []Binding
+	if err := c.get(ctx, c.bindingPath(source, destination, toExchange), nil, &bindings); err != nil {
+		return nil, err
+	}
+
+	return bindings, nil
+}
+
+// ListQueueBindings returns the bindings whose destination is the queue.
+func (c *Client) ListQueueBindings(ctx context.Context, name string) ([]Binding, error) {
+	var bindings []Binding
+	if err := c.get(ctx, fmt.Sprintf("/api/queues/%s/%s/bindings", c.config.VHost, url.PathEscape(name)), nil, &bindings); err != nil {
+		return nil, err
+	}
+
+	return bindings, nil
+}
+
+// ListExchangeBindings returns the bindings whose source is the exchange.
+func (c *Client) ListExchangeBindings(ctx context.Context, name string) ([]Binding, error) {
+	var bindings []Binding
+	if err := c.get(ctx, fmt.Sprintf("/api/exchanges/%s/%s/bindings/source", c.config.VHost, url.PathEscape(name)), nil, &bindings); err != nil {
+		return nil, err
+	}
+
+	return bindings, nil
+}
+
+// GetMessages fetches messages from the head of the queue.
+func (c *Client) GetMessages(ctx context.Context, queue string, request GetMessagesRequest) ([]Message, error) {
+	var messages []Message
+	if _, err := c.do(ctx, http.MethodPost, c.url(fmt.Sprintf("/api/queues/%s/%s/get", c.config.VHost, url.PathEscape(queue)), nil), request, &messages); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// DeclareQueue creates the queue, or does nothing if it already exists with the same settings. Differing
+// settings are reported as a 400 StatusError.
+func (c *Client) DeclareQueue(ctx context.Context, name string, settings QueueSettings) error {
+	_, err := c.do(ctx, http.MethodPut, c.url(fmt.Sprintf("/api/queues/%s/%s", c.config.VHost, url.PathEscape(name)), nil), settings, nil)
+	return err
+}
+
+// DeleteQueue deletes the queue. With ifEmpty or ifUnused set the broker refuses, with a 400 StatusError, to
+// delete a queue that has messages or consumers.
+func (c *Client) DeleteQueue(ctx context.Context, name string, ifEmpty, ifUnused bool) error {
+	query := url.Values{}
+	if ifEmpty {
+		query.Set("if-empty", "true")
+	}
+	if ifUnused {
+		query.Set("if-unused", "true")
+	}
+
+	_, err := c.do(ctx, http.MethodDelete, c.url(fmt.Sprintf("/api/queues/%s/%s", c.config.VHost, url.PathEscape(name)), query), nil, nil)
+	return err
+}
+
+// DeclareExchange creates the exchange, or does nothing if it already exists with the same settings.
+// Differing settings are reported as a 400 StatusError.
+func (c *Client) DeclareExchange(ctx context.Context, name string, settings ExchangeSettings) error {
+	_, err := c.do(ctx, http.MethodPut, c.url(fmt.Sprintf("/api/exchanges/%s/%s", c.config.VHost, url.PathEscape(name)), nil), settings, nil)
+	return err
+}
+
+// DeleteExchange deletes the exchange. With ifUnused set the broker refuses, with a 400 StatusError, to
+// delete an exchange that is the source of a binding.
+func (c *Client) DeleteExchange(ctx context.Context, name string, ifUnused bool) error {
+	query := url.Values{}
+	if ifUnused {
+		query.Set("if-unused", "true")
+	}
+
+	_, err := c.do(ctx, http.MethodDelete, c.url(fmt.Sprintf("/api/exchanges/%s/%s", c.config.VHost, url.PathEscape(name)), query), nil, nil)
+	return err
+}
+
+// CreateBinding binds the destination queue, or exchange when toExchange is set, to the source exchange and
+// returns the properties key that identifies the new binding.
+func (c *Client) CreateBinding(ctx context.Context, source, destination string, toExchange bool, settings BindingSettings) (string, error) {
+	header, err := c.do(ctx, http.MethodPost, c.url(c.bindingPath(source, destination, toExchange), nil), settings, nil)
+	if err != nil {
+		return "", err
+	}
+
+	// the Location header ends with the properties key of the new binding
+	location := header.Get("Location")
+	propertiesKey, err := url.PathUnescape(location[strings.LastIndex(location, "/")+1:])
+	if err != nil {
+		return "", fmt.Errorf("parse binding location %q: %w", location, err)
+	}
+
+	return propertiesKey, nil
+}
+
+func (c *Client) GetBinding(ctx context.Context, source, destination string, toExchange bool, propertiesKey string) (*Binding, error) {
+	var binding Binding
+	if err := c.get(ctx, c.bindingPath(source, destination, toExchange)+"/"+url.PathEscape(propertiesKey), nil, &binding); err != nil {
+		return nil, err
+	}
+
+	return &binding, nil
+}
+
+func (c *Client) DeleteBinding(ctx context.Context, source, destination string, toExchange bool, propertiesKey string) error {
+	_, err := c.do(ctx, http.MethodDelete, c.url(c.bindingPath(source, destination, toExchange)+"/"+url.PathEscape(propertiesKey), nil), nil, nil)
+	return err
+}
+
+func (c *Client) GetPolicy(ctx context.Context, name string) (*Policy, error) {
+	var policy Policy
+	if err := c.get(ctx, fmt.Sprintf("/api/policies/%s/%s", c.config.VHost, url.PathEscape(name)), nil, &policy); err != nil {
+		return nil, err
+	}
+
+	return &policy, nil
+}
+
+// SetPolicy creates the policy or replaces its settings. The broker applies it to the matching queues or
+// exchanges, including the ones that already exist.
+func (c *Client) SetPolicy(ctx context.Context, name string, settings PolicySettings) error {
+	_, err := c.do(ctx, http.MethodPut, c.url(fmt.Sprintf("/api/policies/%s/%s", c.config.VHost, url.PathEscape(name)), nil), settings, nil)
+	return err
+}
+
+func (c *Client) bindingPath(source, destination string, toExchange bool) string {
+	destinationType := "q"
+	if toExchange {
+		destinationType = "e"
+	}
+
+	return fmt.Sprintf("/api/bindings/%s/e/%s/%s/%s", c.config.VHost, url.PathEscape(source), destinationType, url.PathEscape(destination))
+}
+
+// list calls a list endpoint. Without a page the API returns a plain array, which is wrapped into a single
+// page so that callers always get the paging metadata.
+func list[T any](ctx context.Context, c *Client, path string, params *ListParams) (*Page[T], error) {
+	query := params.query()
+
+	if params != nil && params.Page > 0 {
+		var page Page[T]
+		if err := c.get(ctx, path, query, &page); err != nil {
+			return nil, err
+		}
+		return &page, nil
+	}
+
+	var items []T
+	if err := c.get(ctx, path, query, &items); err != nil {
+		return nil, err
+	}
+
+	return &Page[T]{
+		Items:         items,
+		Page:          1,
+		PageSize:      len(items),
+		PageCount:     1,
+		ItemCount:     len(items),
+		FilteredCount: len(items),
+		TotalCount:    len(items),
+	}, nil
+}
+
+func (p *ListParams) query() url.Values {
+	query := url.Values{}
+	if p == nil {
+		return query
+	}
+
+	if p.Page > 0 {
+		query.Set("page", strconv.Itoa(p.Page))
+		if p.PageSize > 0 {
+			query.Set("page_size", strconv.Itoa(p.PageSize))
+		}
+	}
+	if p.Name != "" {
+		query.Set("name", p.Name)
+		if p.UseRegex {
+			query.Set("use_regex", "true")
+		}
+	}
+	if p.Sort != "" {
+		query.Set("sort", p.Sort)
+		if p.SortReverse {
+			query.Set("sort_reverse", "true")
+		}
+	}
+	if len(p.Columns) > 0 {
+		query.Set("columns", strings.Join(p.Columns, ","))
+	}
+
+	return query
+}
+
+// get sends a GET request, retrying it on network errors and 5xx responses, and decodes the response into
+// out.
+func (c *Client) get(ctx context.Context, path string, query url.Values, out any) error {
+	target := c.url(path, query)
+	delay := c.config.RetryBackoff
+
+	for attempt := 0; ; attempt++ {
+		_, err := c.do(ctx, http.MethodGet, target, nil, out)
+		if err == nil || attempt >= c.config.MaxRetries || !retryable(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+func (c *Client) url(path string, query url.Values) string {
+	target := c.config.Address + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	return target
+}
+
+// do sends a single request with body, if any, encoded as JSON and decodes the response into out, if any.
+func (c *Client) do(ctx context.Context, method, target string, body, out any) (http.Header, error) {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("encode request body: %w", err)
+		}
+		reader = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+	req.SetBasicAuth(c.config.Username, c.config.Password)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("send request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, ErrNotFound
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return nil, statusError(resp)
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return resp.Header, nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return nil, fmt.Errorf("decode response body: %w", err)
+	}
+
+	return resp.Header, nil
+}
+
+// statusError reads the reason out of an error response, which the API sends as {"error": ..., "reason": ...}.
+func statusError(resp *http.Response) *StatusError {
+	raw, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+
+	var body struct {
+		Reason string `json:"reason"`
+	}
+	reason := strings.TrimSpace(string(raw))
+	if err := json.Unmarshal(raw, &body); err == nil && body.Reason != "" {
+		reason = body.Reason
+	}
+
+	return &StatusError{StatusCode: resp.StatusCode, Status: resp.Status, Reason: reason}
+}
+
+func retryable(err error) bool {
+	if errors.Is(err, ErrNotFound) || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode >= 500
+	}
+
+	// network errors
+	return true
+}
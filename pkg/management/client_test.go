@@ -0,0 +1,323 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package management
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type recordedRequest struct {
+	method      string
+	path        string // escaped, as sent
+	query       string
+	contentType string
+	body        string
+	username    string
+	password    string
+}
+
+// newTestServer starts a management API answering every request with handler, and a client for it.
+func newTestServer(t *testing.T, handler http.HandlerFunc) (*Client, func() []recordedRequest) {
+	t.Helper()
+
+	var (
+		mu       sync.Mutex
+		requests []recordedRequest
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		username, password, _ := r.BasicAuth()
+
+		mu.Lock()
+		requests = append(requests, recordedRequest{
+			method:      r.Method,
+			path:        r.URL.EscapedPath(),
+			query:       r.URL.RawQuery,
+			contentType: r.Header.Get("Content-Type"),
+			body:        string(body),
+			username:    username,
+			password:    password,
+		})
+		mu.Unlock()
+
+		handler(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(Config{
+		Address:      server.URL,
+		VHost:        "%2F",
+		Username:     "admin",
+		Password:     "admin123",
+		MaxRetries:   2,
+		RetryBackoff: time.Millisecond,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client, func() []recordedRequest {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]recordedRequest(nil), requests...)
+	}
+}
+
+func respondJSON(body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = io.WriteString(w, body)
+	}
+}
+
+func TestClientRequests(t *testing.T) {
+	tests := []struct {
+		name      string
+		response  string
+		call      func(ctx context.Context, c *Client) error
+		wantPath  string
+		wantQuery string
+		method    string
+	}{
+		{
+			name:     "queue with reserved characters",
+			response: `{"name": "orders/eu west"}`,
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.GetQueue(ctx, "orders/eu west")
+				return err
+			},
+			method:   http.MethodGet,
+			wantPath: "/api/queues/%2F/orders%2Feu%20west",
+		},
+		{
+			name:     "exchange with a question mark",
+			response: `{"name": "what?"}`,
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.GetExchange(ctx, "what?")
+				return err
+			},
+			method:   http.MethodGet,
+			wantPath: "/api/exchanges/%2F/what%3F",
+		},
+		{
+			name:     "binding properties key",
+			response: `{"source": "exchange_notification"}`,
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.GetBinding(ctx, "exchange_notification", "queue/a", false, "key/with%percent")
+				return err
+			},
+			method:   http.MethodGet,
+			wantPath: "/api/bindings/%2F/e/exchange_notification/q/queue%2Fa/key%2Fwith%25percent",
+		},
+		{
+			name:     "exchange to exchange binding",
+			response: `[]`,
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.ListBindingsBetween(ctx, "source", "destination", true)
+				return err
+			},
+			method:   http.MethodGet,
+			wantPath: "/api/bindings/%2F/e/source/e/destination",
+		},
+		{
+			name: "delete queue conditions",
+			call: func(ctx context.Context, c *Client) error {
+				return c.DeleteQueue(ctx, "queue_test", true, true)
+			},
+			method:    http.MethodDelete,
+			wantPath:  "/api/queues/%2F/queue_test",
+			wantQuery: "if-empty=true&if-unused=true",
+		},
+		{
+			name:     "paged list",
+			response: `{"items": [], "page": 2, "page_size": 10}`,
+			call: func(ctx context.Context, c *Client) error {
+				_, err := c.ListQueues(ctx, &ListParams{Page: 2, PageSize: 10, Name: "^queue_", UseRegex: true, Sort: "messages", SortReverse: true, Columns: []string{"name", "messages"}})
+				return err
+			},
+			method:    http.MethodGet,
+			wantPath:  "/api/queues/%2F",
+			wantQuery: "columns=name%2Cmessages&name=%5Equeue_&page=2&page_size=10&sort=messages&sort_reverse=true&use_regex=true",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, requests := newTestServer(t, respondJSON(tt.response))
+
+			if err := tt.call(context.Background(), client); err != nil {
+				t.Fatalf("call: %v", err)
+			}
+
+			got := requests()
+			if len(got) != 1 {
+				t.Fatalf("sent %d requests, want 1", len(got))
+			}
+			if r := got[0]; r.method != tt.method || r.path != tt.wantPath || r.query != tt.wantQuery {
+				t.Errorf("sent %s %s?%s, want %s %s?%s", r.method, r.path, r.query, tt.method, tt.wantPath, tt.wantQuery)
+			}
+			if r := got[0]; r.username != "admin" || r.password != "admin123" {
+				t.Errorf("authenticated as %q:%q, want admin:admin123", r.username, r.password)
+			}
+		})
+	}
+}
+
+func TestClientUnpagedList(t *testing.T) {
+	client, _ := newTestServer(t, respondJSON(`[{"name": "a"}, {"name": "b"}]`))
+
+	page, err := client.ListQueues(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("ListQueues: %v", err)
+	}
+	if len(page.Items) != 2 || page.Page != 1 || page.PageCount != 1 || page.TotalCount != 2 || page.Items[1].Name != "b" {
+		t.Errorf("ListQueues = %+v, want the array as a single page", page)
+	}
+}
+
+func TestClientErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		post         bool
+		wantErr      error
+		wantStatus   int
+		wantReason   string
+		wantRequests int
+	}{
+		{name: "not found", status: http.StatusNotFound, body: `{"error": "Object Not Found", "reason": "Not Found"}`, wantErr: ErrNotFound, wantRequests: 1},
+		{name: "reason", status: http.StatusBadRequest, body: `{"error": "bad_request", "reason": "inequivalent arg 'durable'"}`, wantStatus: http.StatusBadRequest, wantReason: "inequivalent arg 'durable'", wantRequests: 1},
+		{name: "plain text", status: http.StatusUnauthorized, body: "Not_Authorized\n", wantStatus: http.StatusUnauthorized, wantReason: "Not_Authorized", wantRequests: 1},
+		{name: "GET retried on 5xx", status: http.StatusServiceUnavailable, body: `{"reason": "busy"}`, wantStatus: http.StatusServiceUnavailable, wantReason: "busy", wantRequests: 3},
+		{name: "POST not retried", status: http.StatusServiceUnavailable, body: `{"reason": "busy"}`, post: true, wantStatus: http.StatusServiceUnavailable, wantReason: "busy", wantRequests: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, requests := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = io.WriteString(w, tt.body)
+			})
+
+			var err error
+			if tt.post {
+				_, err = client.GetMessages(context.Background(), "queue_test", GetMessagesRequest{Count: 1})
+			} else {
+				_, err = client.GetQueue(context.Background(), "queue_test")
+			}
+
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("error = %v, want %v", err, tt.wantErr)
+				}
+			} else {
+				var statusErr *StatusError
+				if !errors.As(err, &statusErr) || statusErr.StatusCode != tt.wantStatus || statusErr.Reason != tt.wantReason {
+					t.Errorf("error = %#v, want a %d StatusError with reason %q", err, tt.wantStatus, tt.wantReason)
+				}
+			}
+
+			if got := len(requests()); got != tt.wantRequests {
+				t.Errorf("sent %d requests, want %d", got, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestClientRetryRecovers(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+
+	client, _ := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		first := calls == 1
+		mu.Unlock()
+
+		if first {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		respondJSON(`{"name": "queue_test"}`)(w, r)
+	})
+
+	queue, err := client.GetQueue(context.Background(), "queue_test")
+	if err != nil || queue.Name != "queue_test" {
+		t.Fatalf("GetQueue = %+v, %v, want the queue after a retry", queue, err)
+	}
+}
+
+func TestClientGetMessages(t *testing.T) {
+	client, requests := newTestServer(t, respondJSON(`[{"payload_bytes": 2, "redelivered": true, "routing_key": "notification", "message_count": 4, "properties": {"content_type": "application/json", "headers": {"x-retry-attempt": 1}}, "payload": "{}", "payload_encoding": "string"}]`))
+
+	messages, err := client.GetMessages(context.Background(), "queue/test", GetMessagesRequest{
+		Count:    5,
+		AckMode:  AckModeRequeue,
+		Encoding: "auto",
+		Truncate: 1000,
+	})
+	if err != nil {
+		t.Fatalf("GetMessages: %v", err)
+	}
+
+	got := requests()
+	if len(got) != 1 {
+		t.Fatalf("sent %d requests, want 1", len(got))
+	}
+	if r := got[0]; r.method != http.MethodPost || r.path != "/api/queues/%2F/queue%2Ftest/get" || r.contentType != "application/json" {
+		t.Errorf("sent %s %s as %q", r.method, r.path, r.contentType)
+	}
+
+	var body map[string]any
+	if err := json.Unmarshal([]byte(got[0].body), &body); err != nil {
+		t.Fatalf("request body %q: %v", got[0].body, err)
+	}
+	want := map[string]any{"count": float64(5), "ackmode": "ack_requeue_true", "encoding": "auto", "truncate": float64(1000)}
+	for k, v := range want {
+		if body[k] != v {
+			t.Errorf("request body %s = %v, want %v", k, body[k], v)
+		}
+	}
+	if len(body) != len(want) {
+		t.Errorf("request body %v, want %v", body, want)
+	}
+
+	if len(messages) != 1 {
+		t.Fatalf("got %d messages, want 1", len(messages))
+	}
+	if m := messages[0]; !m.Redelivered || m.RoutingKey != "notification" || m.MessageCount != 4 || m.Payload != "{}" || m.Properties.ContentType != "application/json" {
+		t.Errorf("message = %+v", m)
+	}
+}
+
+func TestClientCreateBinding(t *testing.T) {
+	client, requests := newTestServer(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Location", "/api/bindings/%2F/e/exchange_notification/q/queue_test/notification.%23~abc")
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	propertiesKey, err := client.CreateBinding(context.Background(), "exchange_notification", "queue_test", false, BindingSettings{RoutingKey: "notification.#"})
+	if err != nil {
+		t.Fatalf("CreateBinding: %v", err)
+	}
+	if propertiesKey != "notification.#~abc" {
+		t.Errorf("properties key = %q, want notification.#~abc", propertiesKey)
+	}
+
+	if r := requests()[0]; r.method != http.MethodPost || r.path != "/api/bindings/%2F/e/exchange_notification/q/queue_test" || r.body != `{"routing_key":"notification.#"}` {
+		t.Errorf("sent %s %s %s", r.method, r.path, r.body)
+	}
+}
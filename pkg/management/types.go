@@ -0,0 +1,199 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package management
+
+import "encoding/json"
+
+// Response shapes of the RabbitMQ management API, limited to the fields this project uses.
+
+type Rate struct {
+	Rate float64 `json:"rate"`
+}
+
+type Exchange struct {
+	Name         string         `json:"name"`
+	VHost        string         `json:"vhost"`
+	Type         string         `json:"type"`
+	Durable      bool           `json:"durable"`
+	AutoDelete   bool           `json:"auto_delete"`
+	Internal     bool           `json:"internal"`
+	Arguments    map[string]any `json:"arguments"`
+	Policy       string         `json:"policy"`
+	MessageStats struct {
+		PublishInDetails  Rate `json:"publish_in_details"`
+		PublishOutDetails Rate `json:"publish_out_details"`
+	} `json:"message_stats"`
+}
+
+type Queue struct {
+	Name                       string            `json:"name"`
+	VHost                      string            `json:"vhost"`
+	Type                       string            `json:"type"`
+	State                      string            `json:"state"`
+	Durable                    bool              `json:"durable"`
+	AutoDelete                 bool              `json:"auto_delete"`
+	Exclusive                  bool              `json:"exclusive"`
+	Messages                   int64             `json:"messages"`
+	MessagesReady              int64             `json:"messages_ready"`
+	MessagesUnacknowledged     int64             `json:"messages_unacknowledged"`
+	Consumers                  int               `json:"consumers"`
+	Arguments                  map[string]any    `json:"arguments"`
+	Policy                     string            `json:"policy"`
+	Node                       string            `json:"node"`
+	Memory                     int64             `json:"memory"`
+	MessageBytes               int64             `json:"message_bytes"`
+	MessageBytesReady          int64             `json:"message_bytes_ready"`
+	MessageBytesUnacknowledged int64             `json:"message_bytes_unacknowledged"`
+	ConsumerUtilisation        float64           `json:"consumer_utilisation"`
+	IdleSince                  string            `json:"idle_since"`
+	ConsumerDetails            []ConsumerDetails `json:"consumer_details"` // only returned for a single queue
+	MessageStats               struct {
+		PublishDetails    Rate `json:"publish_details"`
+		DeliverGetDetails Rate `json:"deliver_get_details"`
+		AckDetails        Rate `json:"ack_details"`
+		RedeliverDetails  Rate `json:"redeliver_details"`
+	} `json:"message_stats"`
+}
+
+type ConsumerDetails struct {
+	ConsumerTag    string `json:"consumer_tag"`
+	AckRequired    bool   `json:"ack_required"`
+	PrefetchCount  int    `json:"prefetch_count"`
+	Active         bool   `json:"active"`
+	ChannelDetails struct {
+		Name           string `json:"name"`
+		ConnectionName string `json:"connection_name"`
+		PeerHost       string `json:"peer_host"`
+	} `json:"channel_details"`
+}
+
+type Binding struct {
+	Source          string         `json:"source"`
+	Destination     string         `json:"destination"`
+	DestinationType string         `json:"destination_type"`
+	VHost           string         `json:"vhost"`
+	RoutingKey      string         `json:"routing_key"`
+	Arguments       map[string]any `json:"arguments"`
+	PropertiesKey   string         `json:"properties_key"`
+}
+
+// Page is a page of a list endpoint, as returned by the API when it is called with a page parameter.
+type Page[T any] struct {
+	Items         []T `json:"items"`
+	Page          int `json:"page"`
+	PageSize      int `json:"page_size"`
+	PageCount     int `json:"page_count"`
+	ItemCount     int `json:"item_count"`
+	FilteredCount int `json:"filtered_count"`
+	TotalCount    int `json:"total_count"`
+}
+
+// ListParams are the pagination, filtering and sorting parameters supported by the queue and exchange
+// list endpoints. Zero values are left out of the request.
+type ListParams struct {
+	Page        int
+	PageSize    int
+	Name        string // Filter on the name, a substring unless UseRegex is set
+	UseRegex    bool
+	Sort        string // Field to sort on, e.g. name or messages
+	SortReverse bool
+	Columns     []string // Restrict the returned fields, e.g. name and messages
+}
+
+// QueueSettings are the properties a queue is declared with.
+type QueueSettings struct {
+	Durable    bool           `json:"durable"`
+	AutoDelete bool           `json:"auto_delete"`
+	Arguments  map[string]any `json:"arguments,omitempty"`
+}
+
+// ExchangeSettings are the properties an exchange is declared with.
+type ExchangeSettings struct {
+	Type       string         `json:"type"`
+	Durable    bool           `json:"durable"`
+	AutoDelete bool           `json:"auto_delete"`
+	Internal   bool           `json:"internal"`
+	Arguments  map[string]any `json:"arguments,omitempty"`
+}
+
+// BindingSettings are the properties a binding is created with.
+type BindingSettings struct {
+	RoutingKey string         `json:"routing_key"`
+	Arguments  map[string]any `json:"arguments,omitempty"`
+}
+
+// Policy is a policy of the vhost.
+type Policy struct {
+	Name       string         `json:"name"`
+	Pattern    string         `json:"pattern"`
+	ApplyTo    string         `json:"apply-to"`
+	Priority   int            `json:"priority"`
+	Definition map[string]any `json:"definition"`
+}
+
+// PolicySettings are the properties a policy is set with.
+type PolicySettings struct {
+	Pattern    string         `json:"pattern"`
+	ApplyTo    string         `json:"apply-to"`
+	Priority   int            `json:"priority"`
+	Definition map[string]any `json:"definition"`
+}
+
+type AckMode string
+
+const (
+	// AckModeRequeue puts fetched messages back in the queue, where they are flagged as redelivered.
+	AckModeRequeue AckMode = "ack_requeue_true"
+	// AckModeReject removes fetched messages, dead-lettering them if the queue has a dead-letter exchange.
+	AckModeReject AckMode = "reject_requeue_false"
+)
+
+// GetMessagesRequest is the body of a get-messages request. Fetching a message is a basic.get, so it is not
+// a read-only operation whatever the AckMode.
+type GetMessagesRequest struct {
+	Count    int     `json:"count"`
+	AckMode  AckMode `json:"ackmode"`
+	Encoding string  `json:"encoding"`           // auto returns UTF-8 payloads as strings and others as base64
+	Truncate int     `json:"truncate,omitempty"` // Maximum payload bytes returned
+}
+
+type MessageProperties struct {
+	ContentType     string         `json:"content_type"`
+	ContentEncoding string         `json:"content_encoding"`
+	DeliveryMode    int            `json:"delivery_mode"`
+	Priority        int            `json:"priority"`
+	CorrelationID   string         `json:"correlation_id"`
+	ReplyTo         string         `json:"reply_to"`
+	Expiration      string         `json:"expiration"`
+	MessageID       string         `json:"message_id"`
+	Timestamp       int64          `json:"timestamp"` // Seconds since the epoch
+	Type            string         `json:"type"`
+	UserID          string         `json:"user_id"`
+	AppID           string         `json:"app_id"`
+	Headers         map[string]any `json:"headers"`
+}
+
+// UnmarshalJSON accepts the empty array the API sends instead of an object for a message without properties.
+func (p *MessageProperties) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '[' {
+		*p = MessageProperties{}
+		return nil
+	}
+
+	type plain MessageProperties
+	return json.Unmarshal(data, (*plain)(p))
+}
+
+type Message struct {
+	PayloadBytes    int               `json:"payload_bytes"`
+	Redelivered     bool              `json:"redelivered"`
+	Exchange        string            `json:"exchange"`
+	RoutingKey      string            `json:"routing_key"`
+	MessageCount    int               `json:"message_count"` // Messages left in the queue after this one
+	Properties      MessageProperties `json:"properties"`
+	Payload         string            `json:"payload"`
+	PayloadEncoding string            `json:"payload_encoding"` // string or base64
+}
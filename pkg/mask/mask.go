@@ -0,0 +1,118 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package mask
+
+import (
+	"reflect"
+	"strings"
+)
+
+const (
+	tag         = "sensitive"
+	placeholder = "***"
+)
+
+// String hides most of s while keeping enough to tell values apart: the first character and the domain of
+// an email address, the first and last two characters of anything else.
+func String(s string) string {
+	if s == "" {
+		return s
+	}
+
+	if at := strings.LastIndex(s, "@"); at > 0 {
+		return s[:1] + placeholder + s[at:]
+	}
+
+	runes := []rune(s)
+	if len(runes) <= 6 {
+		return placeholder
+	}
+
+	return string(runes[:2]) + placeholder + string(runes[len(runes)-2:])
+}
+
+// Sensitive masks, in place, the fields tagged `sensitive:"true"` of the struct v points to, including those
+// of nested structs reached through pointers, slices and maps. A tagged string is masked with String and
+// every string in a tagged map or slice is masked the same way.
+func Sensitive(v any) {
+	walk(reflect.ValueOf(v))
+}
+
+func walk(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			walk(v.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			walk(v.Index(i))
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			// map values aren't addressable, mask a copy and put it back
+			value := reflect.New(iter.Value().Type()).Elem()
+			value.Set(iter.Value())
+			walk(value)
+			v.SetMapIndex(iter.Key(), value)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Field(i)
+			if !field.CanSet() {
+				continue
+			}
+
+			if t.Field(i).Tag.Get(tag) == "true" {
+				maskValue(field)
+			} else {
+				walk(field)
+			}
+		}
+	}
+}
+
+// maskValue masks every string reachable from v.
+func maskValue(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.String:
+		if v.CanSet() {
+			v.SetString(String(v.String()))
+		}
+	case reflect.Pointer:
+		if !v.IsNil() {
+			maskValue(v.Elem())
+		}
+	case reflect.Interface:
+		if v.IsNil() || !v.CanSet() {
+			return
+		}
+		value := reflect.New(v.Elem().Type()).Elem()
+		value.Set(v.Elem())
+		maskValue(value)
+		v.Set(value)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			maskValue(v.Index(i))
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			value := reflect.New(iter.Value().Type()).Elem()
+			value.Set(iter.Value())
+			maskValue(value)
+			v.SetMapIndex(iter.Key(), value)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).CanSet() {
+				maskValue(v.Field(i))
+			}
+		}
+	}
+}
@@ -0,0 +1,137 @@
+/**
+ * Author : ngdangkietswe
+ * Since  : 10/18/2026
+ */
+
+package mask
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{in: "", want: ""},
+		{in: "kiet@example.com", want: "k***@example.com"},
+		{in: "a.b@c@example.com", want: "a***@example.com"},
+		{in: "@example.com", want: "@e***om"},
+		{in: "123456", want: "***"},
+		{in: "+84912345678", want: "+8***78"},
+		{in: "nguyễn văn a", want: "ng*** a"},
+		{in: "tổng", want: "***"},
+	}
+
+	for _, tt := range tests {
+		if got := String(tt.in); got != tt.want {
+			t.Errorf("String(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+type contact struct {
+	Name  string
+	Email string `sensitive:"true"`
+}
+
+type account struct {
+	ID       string
+	Owner    contact
+	Backup   *contact
+	Contacts []contact
+	ByRole   map[string]*contact
+	Phones   []string          `sensitive:"true"`
+	Secrets  map[string]string `sensitive:"true"`
+	Extra    map[string]any    `sensitive:"true"`
+	Token    *string           `sensitive:"true"`
+	PIN      int               `sensitive:"true"`
+	Note     any               `sensitive:"true"`
+	Nested   *contact          `sensitive:"true"`
+	Missing  *contact          `sensitive:"true"`
+	Unset    map[string]string `sensitive:"true"`
+	internal string            `sensitive:"true"`
+}
+
+func TestSensitive(t *testing.T) {
+	token := "tok_1234567890"
+
+	tests := []struct {
+		name string
+		in   any
+		want any
+	}{
+		{
+			name: "nested structs, pointers, slices and maps",
+			in: &account{
+				ID:       "acc-1234567",
+				Owner:    contact{Name: "Kiet", Email: "kiet@example.com"},
+				Backup:   &contact{Name: "Backup", Email: "backup@example.com"},
+				Contacts: []contact{{Name: "A", Email: "a@example.com"}},
+				ByRole:   map[string]*contact{"admin": {Name: "Admin", Email: "admin@example.com"}},
+				Phones:   []string{"+84912345678"},
+				Secrets:  map[string]string{"api": "secret-value"},
+				Extra:    map[string]any{"phone": "+84912345678", "tries": 3, "nested": map[string]any{"email": "x@example.com"}},
+				Token:    &token,
+				PIN:      123456,
+				Note:     "call me at 0912345678",
+				Nested:   &contact{Name: "Nguyen Van A", Email: "a@example.com"},
+				internal: "kept-as-is",
+			},
+			want: &account{
+				ID:       "acc-1234567",
+				Owner:    contact{Name: "Kiet", Email: "k***@example.com"},
+				Backup:   &contact{Name: "Backup", Email: "b***@example.com"},
+				Contacts: []contact{{Name: "A", Email: "a***@example.com"}},
+				ByRole:   map[string]*contact{"admin": {Name: "Admin", Email: "a***@example.com"}},
+				Phones:   []string{"+8***78"},
+				Secrets:  map[string]string{"api": "se***ue"},
+				Extra:    map[string]any{"phone": "+8***78", "tries": 3, "nested": map[string]any{"email": "x***@example.com"}},
+				Token:    stringPointer("to***90"),
+				PIN:      123456,
+				Note:     "ca***78",
+				Nested:   &contact{Name: "Ng*** A", Email: "a***@example.com"},
+				internal: "kept-as-is",
+			},
+		},
+		{
+			name: "nil values",
+			in:   &account{Owner: contact{Name: "Kiet"}},
+			want: &account{Owner: contact{Name: "Kiet"}},
+		},
+		{
+			name: "slice of structs",
+			in:   &[]contact{{Name: "Kiet", Email: "kiet@example.com"}},
+			want: &[]contact{{Name: "Kiet", Email: "k***@example.com"}},
+		},
+		{
+			name: "map of structs",
+			in:   map[string]*contact{"kiet": {Name: "Kiet", Email: "kiet@example.com"}},
+			want: map[string]*contact{"kiet": {Name: "Kiet", Email: "k***@example.com"}},
+		},
+		{
+			name: "struct passed by value can't be masked",
+			in:   contact{Name: "Kiet", Email: "kiet@example.com"},
+			want: contact{Name: "Kiet", Email: "kiet@example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			Sensitive(tt.in)
+			if !reflect.DeepEqual(tt.in, tt.want) {
+				t.Errorf("Sensitive = %+v, want %+v", tt.in, tt.want)
+			}
+		})
+	}
+
+	// nil pointers and interfaces are left alone
+	Sensitive(nil)
+	Sensitive((*account)(nil))
+}
+
+func stringPointer(s string) *string {
+	return &s
+}